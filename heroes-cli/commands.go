@@ -0,0 +1,533 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-client"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+var errUsage = errors.New("invalid usage, run heroes --help")
+
+// execute runs the resource command described by the positional arguments.
+func execute(ctx context.Context, opts options, args []string, stdin io.Reader, stdout io.Writer) error {
+	if args[0] == "config" {
+		return configCommand(opts, args[1:], stdout)
+	}
+
+	c, err := newClient(opts)
+	if err != nil {
+		return err
+	}
+
+	cmd := command{ctx: ctx, client: c, opts: opts, args: args[2:], stdin: stdin, out: printer{stdout, opts.output}}
+	if opts.includeDeleted {
+		cmd.list = append(cmd.list, client.IncludeDeleted())
+	}
+
+	if opts.filter != "" {
+		cmd.list = append(cmd.list, client.Filter(opts.filter))
+	}
+
+	switch args[0] {
+	case "races":
+		return cmd.races(args[1])
+	case "classes":
+		return cmd.classes(args[1])
+	case "skills":
+		return cmd.skills(args[1])
+	case "proficiencies":
+		return cmd.proficiencies(args[1])
+	case "compendium":
+		return cmd.compendium(args[1])
+	case "search":
+		results, err := c.Search(ctx, strings.Join(args[1:], " "), client.Page{})
+		return cmd.print(results, searchTable(results), err)
+	default:
+		return fmt.Errorf("%w: unknown command %s", errUsage, args[0])
+	}
+}
+
+// command carries everything a resource command needs.
+type command struct {
+	ctx    context.Context
+	client *client.Client
+	opts   options
+	args   []string
+	list   []client.ListOption
+	stdin  io.Reader
+	out    printer
+}
+
+func (c command) races(action string) error {
+	switch action {
+	case "list":
+		races, err := c.client.ListRaces(c.ctx, c.list...)
+		return c.print(races, racesTable(races), err)
+	case "show":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		race, err := c.client.GetRace(c.ctx, id, c.list...)
+		return c.print(race, raceDetails(race), err)
+	case "by-name":
+		name, err := c.name()
+		if err != nil {
+			return err
+		}
+		race, err := c.client.GetRaceByName(c.ctx, name, c.list...)
+		return c.print(race, raceDetails(race), err)
+	case "by-recommended-classes":
+		if len(c.args) == 0 {
+			return fmt.Errorf("%w: missing class names", errUsage)
+		}
+		races, err := c.client.ListRacesByRecommendedClasses(c.ctx, heroes.Match(c.opts.match), c.args...)
+		return c.print(races, racesTable(races), err)
+	default:
+		return write(c, action, raceResource(c.client))
+	}
+}
+
+func (c command) classes(action string) error {
+	switch action {
+	case "list":
+		classes, err := c.client.ListClasses(c.ctx, c.list...)
+		return c.print(classes, classesTable(classes), err)
+	case "show":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		class, err := c.client.GetClass(c.ctx, id, c.list...)
+		return c.print(class, classDetails(class), err)
+	case "by-name":
+		name, err := c.name()
+		if err != nil {
+			return err
+		}
+		class, err := c.client.GetClassByName(c.ctx, name, c.list...)
+		return c.print(class, classDetails(class), err)
+	case "by-role":
+		if len(c.args) != 1 {
+			return fmt.Errorf("%w: expected a single role", errUsage)
+		}
+		classes, err := c.client.ListClassesByRole(c.ctx, heroes.Role(c.args[0]), c.list...)
+		return c.print(classes, classesTable(classes), err)
+	case "by-proficiencies":
+		if len(c.args) == 0 {
+			return fmt.Errorf("%w: missing proficiencies", errUsage)
+		}
+		proficiencies := make([]heroes.ProficiencyType, len(c.args))
+		for i, arg := range c.args {
+			proficiencies[i] = heroes.ProficiencyType(arg)
+		}
+		classes, err := c.client.ListClassesByProficiencies(c.ctx, heroes.Match(c.opts.match), proficiencies...)
+		return c.print(classes, classesTable(classes), err)
+	default:
+		return write(c, action, classResource(c.client))
+	}
+}
+
+func (c command) skills(action string) error {
+	switch action {
+	case "list":
+		skills, err := c.client.ListSkills(c.ctx, c.list...)
+		return c.print(skills, skillsTable(skills), err)
+	case "show":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		skill, err := c.client.GetSkill(c.ctx, id, c.list...)
+		return c.showSkill(skill, err)
+	case "by-name":
+		name, err := c.name()
+		if err != nil {
+			return err
+		}
+		skill, err := c.client.GetSkillByName(c.ctx, name, c.list...)
+		return c.showSkill(skill, err)
+	case "by-type":
+		if len(c.args) != 1 {
+			return fmt.Errorf("%w: expected a single skill type", errUsage)
+		}
+		skills, err := c.client.ListSkillsByType(c.ctx, heroes.SkillType(c.args[0]), c.list...)
+		return c.print(skills, skillsTable(skills), err)
+	case "by-source":
+		if len(c.args) != 1 {
+			return fmt.Errorf("%w: expected a single source", errUsage)
+		}
+		skills, err := c.client.ListSkillsBySource(c.ctx, heroes.Source(c.args[0]), c.list...)
+		return c.print(skills, skillsTable(skills), err)
+	default:
+		return write(c, action, skillResource(c.client))
+	}
+}
+
+// versioned entities carry the version the API checks writes against, such as *heroes.Race.
+type versioned[T any] interface {
+	*T
+	GetVersion() uint64
+	SetVersion(version uint64)
+}
+
+// resource gathers the SDK calls writing a type of entity, so write commands work the same for races, classes and skills.
+type resource[T any, P versioned[T]] struct {
+	name    string
+	get     func(context.Context, uint64, ...client.ListOption) (T, error)
+	create  func(context.Context, T) (T, error)
+	update  func(context.Context, T) (T, error)
+	patch   func(context.Context, uint64, uint64, map[string]interface{}) (T, error)
+	delete  func(context.Context, uint64, uint64) error
+	restore func(context.Context, uint64) (T, error)
+	withID  func(T, uint64) T
+	details func(T) func(io.Writer)
+}
+
+func raceResource(c *client.Client) resource[heroes.Race, *heroes.Race] {
+	return resource[heroes.Race, *heroes.Race]{
+		name: "races", get: c.GetRace, create: c.CreateRace, update: c.UpdateRace, patch: c.PatchRace,
+		delete: c.DeleteRace, restore: c.RestoreRace, details: raceDetails,
+		withID: func(race heroes.Race, id uint64) heroes.Race { race.ID = id; return race },
+	}
+}
+
+func classResource(c *client.Client) resource[heroes.Class, *heroes.Class] {
+	return resource[heroes.Class, *heroes.Class]{
+		name: "classes", get: c.GetClass, create: c.CreateClass, update: c.UpdateClass, patch: c.PatchClass,
+		delete: c.DeleteClass, restore: c.RestoreClass, details: classDetails,
+		withID: func(class heroes.Class, id uint64) heroes.Class { class.ID = id; return class },
+	}
+}
+
+func skillResource(c *client.Client) resource[heroes.Skill, *heroes.Skill] {
+	return resource[heroes.Skill, *heroes.Skill]{
+		name: "skills", get: c.GetSkill, create: c.CreateSkill, update: c.UpdateSkill, patch: c.PatchSkill,
+		delete: c.DeleteSkill, restore: c.RestoreSkill, details: skillDetails,
+		withID: func(skill heroes.Skill, id uint64) heroes.Skill { skill.ID = id; return skill },
+	}
+}
+
+// write runs the create, update, patch, delete and restore commands of the resource.
+func write[T any, P versioned[T]](c command, action string, r resource[T, P]) error {
+	latest := func(id uint64) (uint64, error) {
+		current, err := r.get(c.ctx, id)
+		return P(&current).GetVersion(), err
+	}
+
+	switch action {
+	case "create":
+		var entity T
+		if err := c.body(&entity); err != nil {
+			return err
+		}
+		entity, err := r.create(c.ctx, entity)
+		return c.print(entity, r.details(entity), err)
+	case "update":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		var entity T
+		if err := c.body(&entity); err != nil {
+			return err
+		}
+		entity = r.withID(entity, id)
+		version, err := c.version(id, P(&entity).GetVersion(), latest)
+		if err != nil {
+			return err
+		}
+		P(&entity).SetVersion(version)
+		entity, err = r.update(c.ctx, entity)
+		return c.print(entity, r.details(entity), err)
+	case "patch":
+		id, version, fields, err := c.patch(latest)
+		if err != nil {
+			return err
+		}
+		entity, err := r.patch(c.ctx, id, version, fields)
+		return c.print(entity, r.details(entity), err)
+	case "delete":
+		id, version, err := c.target(latest)
+		if err != nil {
+			return err
+		}
+		return r.delete(c.ctx, id, version)
+	case "restore":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		entity, err := r.restore(c.ctx, id)
+		return c.print(entity, r.details(entity), err)
+	default:
+		return fmt.Errorf("%w: unknown %s command %s", errUsage, r.name, action)
+	}
+}
+
+func (c command) proficiencies(action string) error {
+	switch action {
+	case "list":
+		proficiencies, err := c.client.ListProficiencies(c.ctx, c.list...)
+		return c.print(proficiencies, proficienciesTable(proficiencies), err)
+	case "show":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		proficiency, err := c.client.GetProficiency(c.ctx, id, c.list...)
+		return c.print(proficiency, proficienciesTable([]heroes.Proficiency{proficiency}), err)
+	case "classes":
+		id, err := c.id()
+		if err != nil {
+			return err
+		}
+		classes, err := c.client.ListProficiencyClasses(c.ctx, id, c.list...)
+		return c.print(classes, classesTable(classes), err)
+	default:
+		return fmt.Errorf("%w: unknown proficiencies command %s", errUsage, action)
+	}
+}
+
+// compendium exports every entity to stdout, or imports them from --file.
+func (c command) compendium(action string) error {
+	switch action {
+	case "export":
+		content, err := c.client.ExportCompendium(c.ctx, c.opts.format, c.opts.entity)
+		if err != nil {
+			return err
+		}
+		_, err = c.out.out.Write(content)
+		return err
+	case "import":
+		in, err := c.input("compendium")
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		content, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+
+		format, entity := c.opts.format, c.opts.entity
+		name := strings.TrimSuffix(filepath.Base(c.opts.file), filepath.Ext(c.opts.file))
+		if format == "" {
+			format = map[string]string{".yaml": "yaml", ".yml": "yaml", ".csv": "csv"}[filepath.Ext(c.opts.file)]
+		}
+		if entity == "" && format == "csv" {
+			entity = name
+		}
+
+		report, err := c.client.ImportCompendium(c.ctx, content, format, entity, c.opts.dryRun)
+		if err != nil && !errors.Is(err, client.ErrConflict) {
+			return err
+		}
+
+		if printErr := c.out.print(report, importTable(report)); printErr != nil {
+			return printErr
+		}
+
+		if err == nil && len(report.Conflicts) > 0 {
+			err = fmt.Errorf("%d conflicts, nothing would be imported", len(report.Conflicts))
+		}
+		return err
+	default:
+		return fmt.Errorf("%w: unknown compendium command %s", errUsage, action)
+	}
+}
+
+// showSkill prints the skill details, or its requirement tree with --tree.
+func (c command) showSkill(skill heroes.Skill, err error) error {
+	if err != nil || !c.opts.tree {
+		return c.print(skill, skillDetails(skill), err)
+	}
+
+	err = c.requirements(&skill, map[uint64]bool{skill.ID: true})
+	return c.print(skill, skillTree(skill), err)
+}
+
+// requirements fetches the requirements of every requirement, recursively. Seen skills are not expanded again,
+// so cyclic requirements can't loop forever.
+func (c command) requirements(skill *heroes.Skill, seen map[uint64]bool) error {
+	for i := range skill.SkillRequirements {
+		requirement := &skill.SkillRequirements[i]
+		if seen[requirement.ID] {
+			continue
+		}
+		seen[requirement.ID] = true
+
+		full, err := c.client.GetSkill(c.ctx, requirement.ID)
+		if err != nil {
+			return err
+		}
+
+		*requirement = full
+		if err := c.requirements(requirement, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// print writes the value unless the call that produced it failed.
+func (c command) print(value interface{}, table func(io.Writer), err error) error {
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(value, table)
+}
+
+// id parses the single ID argument.
+func (c command) id() (uint64, error) {
+	if len(c.args) != 1 {
+		return 0, fmt.Errorf("%w: expected a single ID", errUsage)
+	}
+
+	id, err := strconv.ParseUint(c.args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: IDs should be numerical values, received %s", errUsage, c.args[0])
+	}
+
+	return id, nil
+}
+
+// name joins the remaining arguments, so names with spaces don't need quotes: heroes races by-name high elf.
+func (c command) name() (string, error) {
+	if len(c.args) == 0 {
+		return "", fmt.Errorf("%w: missing name", errUsage)
+	}
+
+	return strings.Join(c.args, " "), nil
+}
+
+// target parses the ID argument and finds which version to act upon.
+func (c command) target(latest func(uint64) (uint64, error)) (id uint64, version uint64, err error) {
+	if id, err = c.id(); err != nil {
+		return 0, 0, err
+	}
+
+	version, err = c.version(id, 0, latest)
+	return id, version, err
+}
+
+// version finds which version to act upon: the one in the body, the --expect-version flag, or the latest one with
+// --force.
+// Writes are rejected by the API when the version is outdated, so someone else's changes aren't overwritten by accident.
+func (c command) version(id uint64, body uint64, latest func(uint64) (uint64, error)) (uint64, error) {
+	switch {
+	case body > 0:
+		return body, nil
+	case c.opts.expectVersion > 0:
+		return c.opts.expectVersion, nil
+	case c.opts.force:
+		return latest(id)
+	default:
+		return 0, fmt.Errorf("%w: missing --expect-version, or a version in the body. Use --force to act upon the latest version", errUsage)
+	}
+}
+
+// patch reads the fields to change along with the target. A "version" field in the body wins over --expect-version.
+func (c command) patch(latest func(uint64) (uint64, error)) (uint64, uint64, map[string]interface{}, error) {
+	var fields map[string]interface{}
+	if err := c.body(&fields); err != nil {
+		return 0, 0, nil, err
+	}
+
+	if version, ok := fields["version"].(float64); ok {
+		c.opts.expectVersion = uint64(version)
+		delete(fields, "version")
+	}
+
+	id, version, err := c.target(latest)
+	return id, version, fields, err
+}
+
+// body decodes the JSON file given by --file, or stdin when it's "-".
+func (c command) body(dest interface{}) error {
+	in, err := c.input("JSON body")
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := json.NewDecoder(in).Decode(dest); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	return nil
+}
+
+// input opens the file given by --file, or stdin when it's "-". What tells what the file should hold.
+func (c command) input(what string) (io.ReadCloser, error) {
+	switch c.opts.file {
+	case "":
+		return nil, fmt.Errorf("%w: missing --file with the %s", errUsage, what)
+	case "-":
+		return io.NopCloser(c.stdin), nil
+	default:
+		return os.Open(c.opts.file)
+	}
+}
+
+// configCommand manages the profiles stored in the configuration file.
+func configCommand(opts options, args []string, stdout io.Writer) error {
+	cfg, err := loadConfig(opts.configPath)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "CURRENT\tNAME\tSERVER")
+		for _, name := range names {
+			current := ""
+			if name == cfg.Current {
+				current = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", current, name, cfg.Profiles[name].Server)
+		}
+		return w.Flush()
+	case len(args) == 2 && args[0] == "use":
+		if _, ok := cfg.Profiles[args[1]]; !ok {
+			return errors.New("unknown profile: " + args[1])
+		}
+		cfg.Current = args[1]
+	case len(args) == 2 && args[0] == "set":
+		if opts.server == "" {
+			return fmt.Errorf("%w: missing --server", errUsage)
+		}
+		if cfg.Profiles == nil {
+			cfg.Profiles = map[string]profile{}
+		}
+		cfg.Profiles[args[1]] = profile{Server: opts.server, Token: opts.token}
+		if cfg.Current == "" {
+			cfg.Current = args[1]
+		}
+	default:
+		return fmt.Errorf("%w: expected config list, use <profile> or set <profile>", errUsage)
+	}
+
+	return cfg.save(opts.configPath)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// profile is a named server along with the token to authenticate on it.
+type profile struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token,omitempty"`
+}
+
+// config holds every profile and which one is used when --profile is not provided.
+type config struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]profile `yaml:"profiles,omitempty"`
+}
+
+// configPath is HEROES_CONFIG when set, or heroes/config.yaml under the user's configuration directory.
+func configPath() string {
+	if path := os.Getenv("HEROES_CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+
+	return filepath.Join(dir, "heroes", "config.yaml")
+}
+
+// loadConfig reads the configuration file. A missing file is an empty configuration.
+func loadConfig(path string) (config, error) {
+	var cfg config
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return cfg, err
+	}
+
+	return cfg, yaml.Unmarshal(content, &cfg)
+}
+
+// save writes the configuration file, readable only by its owner since it holds tokens.
+func (c config) save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// resolve picks the server and token to use. Flags win over HEROES_SERVER and HEROES_TOKEN, which win over the profile.
+func (c config) resolve(name string, server string, token string) (profile, error) {
+	if name == "" {
+		name = c.Current
+	}
+
+	var p profile
+	if name != "" {
+		var ok bool
+		if p, ok = c.Profiles[name]; !ok {
+			return p, errors.New("unknown profile: " + name)
+		}
+	}
+
+	for _, value := range []struct {
+		target *string
+		flag   string
+		env    string
+	}{{&p.Server, server, "HEROES_SERVER"}, {&p.Token, token, "HEROES_TOKEN"}} {
+		if value.flag != "" {
+			*value.target = value.flag
+		} else if env := os.Getenv(value.env); env != "" {
+			*value.target = env
+		}
+	}
+
+	if p.Server == "" {
+		p.Server = defaultServer
+	}
+
+	return p, nil
+}
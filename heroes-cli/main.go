@@ -0,0 +1,151 @@
+// Command heroes browses and edits the heroes API from a terminal.
+//
+//	heroes races list
+//	heroes skills show 12 --tree
+//	heroes classes by-role fighter -o yaml
+//	heroes compendium import --file skills.csv --dry-run
+//
+// Servers and tokens can be saved as profiles with "heroes config set <name> --server <url> --token <token>".
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-client"
+)
+
+const usage = `Usage: heroes [flags] <command> [arguments]
+
+Commands:
+  races list | show <id> | by-name <name> | by-recommended-classes <class>... [--match any|all|none]
+  classes list | show <id> | by-name <name> | by-role <role> | by-proficiencies <proficiency>... [--match any|all|none]
+  skills list | show <id> [--tree] | by-name <name> [--tree] | by-type <type> | by-source <source>
+  proficiencies list | show <id> | classes <id>
+  search <words>...
+  compendium export [--format json|yaml|csv] [--entity <entity>] | import --file <file> [--dry-run]
+  races|classes|skills create --file <json> | update|patch <id> --file <json> [--expect-version <n> | --force]
+  races|classes|skills delete <id> --expect-version <n> | --force | restore <id>
+  config list | use <profile> | set <profile> --server <url> [--token <token>]
+
+Flags:
+`
+
+// options are the flags accepted by every command. Flags can be placed anywhere after the command name.
+type options struct {
+	profile        string
+	server         string
+	token          string
+	output         string
+	configPath     string
+	file           string
+	expectVersion  uint64
+	match          string
+	filter         string
+	format         string
+	entity         string
+	tree           bool
+	includeDeleted bool
+	dryRun         bool
+	force          bool
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line, returning the process exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	var opts options
+	flags := flag.NewFlagSet("heroes", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.profile, "profile", "", "configuration profile to use, instead of the current one")
+	flags.StringVar(&opts.server, "server", "", "API address, overriding the profile and HEROES_SERVER")
+	flags.StringVar(&opts.token, "token", "", "administrator token, overriding the profile and HEROES_TOKEN")
+	flags.StringVar(&opts.output, "output", "table", "output format: table, json or yaml")
+	flags.StringVar(&opts.output, "o", "table", "shorthand for --output")
+	flags.StringVar(&opts.configPath, "config", configPath(), "configuration file")
+	flags.StringVar(&opts.file, "file", "", "JSON body of create, update and patch commands, or - for stdin")
+	flags.StringVar(&opts.file, "f", "", "shorthand for --file")
+	flags.Uint64Var(&opts.expectVersion, "expect-version", 0, "expected version of updates, patches and deletes, unless the body has one")
+	flags.BoolVar(&opts.force, "force", false, "act upon the latest version when none is given, overwriting changes made meanwhile")
+	flags.StringVar(&opts.filter, "filter", "", "narrow lists with an expression, like \"type eq spell and mana ne ''\"")
+	flags.StringVar(&opts.match, "match", "any", "whether by-recommended-classes and by-proficiencies results have any, all or none of the values")
+	flags.BoolVar(&opts.tree, "tree", false, "show the whole skill requirement tree")
+	flags.BoolVar(&opts.includeDeleted, "include-deleted", false, "also list soft deleted entries")
+	flags.StringVar(&opts.format, "format", "", "compendium format: json, yaml or csv, guessed from the --file extension on imports")
+	flags.StringVar(&opts.entity, "entity", "", "compendium entity type, required by csv unless the file is named after it, like skills.csv")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "only report what an import would create, update and conflict with")
+
+	positional, err := parseInterspersed(flags, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	if len(positional) < 2 {
+		flags.Usage()
+		return 2
+	}
+
+	if err := execute(ctx, opts, positional, stdin, stdout); err != nil {
+		fmt.Fprintln(stderr, "heroes:", err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// parseInterspersed parses flags placed anywhere among the positional arguments, which the flag package alone doesn't allow.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// newClient builds the API client for the selected profile.
+func newClient(opts options) (*client.Client, error) {
+	cfg, err := loadConfig(opts.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := cfg.resolve(opts.profile, opts.server, opts.token)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientOptions []client.Option
+	if p.Token != "" {
+		clientOptions = append(clientOptions, client.WithToken(p.Token))
+	}
+
+	return client.New(p.Server, clientOptions...)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// fakeAPI answers each path with its canned JSON body, and 404 otherwise.
+func fakeAPI(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`"Record not found."`))
+			return
+		}
+
+		w.Write([]byte(body))
+	}))
+}
+
+func runCommand(t *testing.T, expectedCode int, args ...string) string {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"--config", filepath.Join(t.TempDir(), "config.yaml")}, args...)
+	if code := run(context.Background(), args, strings.NewReader(""), &stdout, &stderr); code != expectedCode {
+		t.Fatalf("Expected exit code %d, found %d: %s", expectedCode, code, stderr.String())
+	}
+
+	return stdout.String()
+}
+
+func Test_RacesList_TABLE(t *testing.T) {
+	server := fakeAPI(map[string]string{"/races": `[{"id": 1, "name": "Dwarf", "base_attributes": {"strength": 4}}]`})
+	defer server.Close()
+
+	out := runCommand(t, 0, "races", "list", "--server", server.URL)
+	if !strings.Contains(out, "NAME") || !strings.Contains(out, "Dwarf") {
+		t.Error("Invalid table found:", out)
+	}
+}
+
+func Test_ClassesByRole_YAML(t *testing.T) {
+	server := fakeAPI(map[string]string{"/classes/by-role/fighter": `[{"id": 1, "name": "Warrior", "role": "fighter"}]`})
+	defer server.Close()
+
+	out := runCommand(t, 0, "--server", server.URL, "classes", "by-role", "fighter", "-o", "yaml")
+	if !strings.Contains(out, "role: fighter") {
+		t.Error("Invalid YAML found:", out)
+	}
+}
+
+func Test_ProficiencyClasses_TABLE(t *testing.T) {
+	server := fakeAPI(map[string]string{"/proficiencies/3/classes": `[{"id": 3, "name": "Wizard", "role": "spellcaster"}]`})
+	defer server.Close()
+
+	out := runCommand(t, 0, "--server", server.URL, "proficiencies", "classes", "3")
+	if !strings.Contains(out, "Wizard") || !strings.Contains(out, "spellcaster") {
+		t.Error("Invalid table found:", out)
+	}
+}
+
+func Test_Search_TABLE(t *testing.T) {
+	server := fakeAPI(map[string]string{"/search": `[{"type": "skills", "id": 3, "name": "Hellfire", "description": "Sets things on fire.", "rank": 0.6}]`})
+	defer server.Close()
+
+	out := runCommand(t, 0, "--server", server.URL, "search", "sets", "on", "fire")
+	if !strings.Contains(out, "skills") || !strings.Contains(out, "Hellfire") {
+		t.Error("Invalid table found:", out)
+	}
+}
+
+func Test_ClassesByProficiencies_MATCH(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	runCommand(t, 0, "--server", server.URL, "classes", "by-proficiencies", "cast_magic", "read_magic", "--match", "all")
+	if query != "match=all&proficiencies=cast_magic&proficiencies=read_magic" {
+		t.Error("Invalid query sent:", query)
+	}
+
+	runCommand(t, 0, "--server", server.URL, "skills", "by-type", "spell", "--filter", "level_requirement eq master")
+	if query != "filter=level_requirement+eq+master" {
+		t.Error("Invalid query sent:", query)
+	}
+}
+
+func Test_SkillsShow_TREE(t *testing.T) {
+	server := fakeAPI(map[string]string{
+		"/skills/3": `{"id": 3, "name": "Hellfire", "skill_requirement": [{"id": 2, "name": "Fireball"}]}`,
+		"/skills/2": `{"id": 2, "name": "Fireball", "skill_requirement": [{"id": 1, "name": "Spark"}, {"id": 3, "name": "Hellfire"}]}`,
+		"/skills/1": `{"id": 1, "name": "Spark"}`,
+	})
+	defer server.Close()
+
+	out := runCommand(t, 0, "--server", server.URL, "skills", "show", "3", "--tree")
+	expected := "Hellfire (#3)\n└── Fireball (#2)\n    ├── Spark (#1)\n    └── Hellfire (#3)\n"
+	if out != expected {
+		t.Errorf("Invalid tree found:\n%s", out)
+	}
+}
+
+func Test_SkillsByName_TREE(t *testing.T) {
+	server := fakeAPI(map[string]string{
+		"/skills/by-name/hell fire": `{"id": 3, "name": "Hellfire", "skill_requirement": [{"id": 1, "name": "Spark"}]}`,
+		"/skills/1":                 `{"id": 1, "name": "Spark"}`,
+	})
+	defer server.Close()
+
+	out := runCommand(t, 0, "--server", server.URL, "skills", "by-name", "hell", "fire", "--tree")
+	if out != "Hellfire (#3)\n└── Spark (#1)\n" {
+		t.Errorf("Invalid tree found:\n%s", out)
+	}
+}
+
+func Test_SkillsShow_NOTFOUND(t *testing.T) {
+	server := fakeAPI(map[string]string{})
+	defer server.Close()
+
+	runCommand(t, 1, "--server", server.URL, "skills", "show", "1000")
+	runCommand(t, 2, "--server", server.URL, "skills", "show", "fireball")
+	runCommand(t, 2, "--server", server.URL, "skills", "by-name")
+}
+
+func Test_Config_PROFILES(t *testing.T) {
+	server := fakeAPI(map[string]string{"/skills": `[{"id": 1, "name": "Spark"}]`})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	var stdout, stderr bytes.Buffer
+	for _, args := range [][]string{
+		{"config", "set", "broken", "--server", "http://localhost:1"},
+		{"config", "set", "local", "--server", server.URL, "--token", "secret"},
+		{"config", "use", "local"},
+		{"skills", "list", "-o", "json"},
+		{"config", "list"},
+	} {
+		if code := run(context.Background(), append([]string{"--config", path}, args...), nil, &stdout, &stderr); code != 0 {
+			t.Fatalf("Command %v failed: %s", args, stderr.String())
+		}
+	}
+
+	if out := stdout.String(); !strings.Contains(out, `"name": "Spark"`) || !regexp.MustCompile(`\*\s+local`).MatchString(out) {
+		t.Error("Invalid output found:", out)
+	}
+
+	cfg, _ := loadConfig(path)
+	if p, _ := cfg.resolve("", "", ""); p.Token != "secret" {
+		t.Error("Invalid profile found:", p)
+	}
+}
+
+func Test_CompendiumImport_DRYRUN(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"dry_run": true, "created": [{"entity": "skills", "key": "spark"}], "updated": [], "unchanged": [],
+			"conflicts": [{"entity": "skills", "id": 3, "key": "hellfire", "message": "version 1 is outdated, found 2"}]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "skills.csv")
+	if err := os.WriteFile(path, []byte("name\nSpark\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := runCommand(t, 1, "--server", server.URL, "compendium", "import", "--file", path, "--dry-run")
+	if query != "dry_run=true&entity=skills&format=csv" {
+		t.Error("Invalid query sent:", query)
+	}
+
+	if !strings.Contains(out, "Dry run") || !regexp.MustCompile(`conflict\s+skills\s+3\s+hellfire`).MatchString(out) {
+		t.Error("Invalid report found:", out)
+	}
+}
+
+func Test_RacesDelete_VERSION(t *testing.T) {
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "Dwarf", "version": 4}`))
+	}))
+	defer server.Close()
+
+	runCommand(t, 2, "--server", server.URL, "races", "delete", "1")
+	runCommand(t, 0, "--server", server.URL, "races", "delete", "1", "--expect-version", "3")
+	runCommand(t, 0, "--server", server.URL, "races", "delete", "1", "--force")
+	if strings.Join(ifMatch, " ") != `"3" "4"` {
+		t.Error("Invalid If-Match sent:", ifMatch)
+	}
+}
+
+func Test_Writes_VERSION(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := io.ReadAll(r.Body)
+			sent = append(sent, strings.Join(strings.Fields(r.Method+" "+r.URL.Path+" "+r.Header.Get("If-Match")+" "+string(body)), " "))
+		}
+		w.Write([]byte(`{"id": 2, "name": "Wizard", "version": 6}`))
+	}))
+	defer server.Close()
+
+	body := filepath.Join(t.TempDir(), "class.json")
+	os.WriteFile(body, []byte(`{"name": "Wizard"}`), 0o600)
+
+	runCommand(t, 0, "--server", server.URL, "classes", "create", "--file", body)
+	runCommand(t, 0, "--server", server.URL, "classes", "update", "2", "--file", body, "--expect-version", "5")
+	runCommand(t, 0, "--server", server.URL, "skills", "patch", "2", "--file", body, "--force")
+	runCommand(t, 0, "--server", server.URL, "races", "restore", "2")
+	runCommand(t, 2, "--server", server.URL, "classes", "update", "2", "--file", body)
+	runCommand(t, 2, "--server", server.URL, "races", "rename", "2")
+
+	expected := []string{
+		`POST /classes {"id":0,`,
+		`PUT /classes/2 "5" {"id":2,`,
+		`PATCH /skills/2 "6" {"name":"Wizard"}`,
+		`POST /races/2/restore`,
+	}
+	if len(sent) != len(expected) {
+		t.Fatal("Invalid requests sent:", sent)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(sent[i], prefix) {
+			t.Errorf("Expected a request starting with %s, found: %s", prefix, sent[i])
+		}
+	}
+}
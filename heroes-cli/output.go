@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-client"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"gopkg.in/yaml.v2"
+)
+
+// printer writes values either as JSON, YAML or as human friendly tables.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// print writes value in the chosen format, calling table to lay it out when the format is table.
+func (p printer) print(value interface{}, table func(w io.Writer)) error {
+	switch p.format {
+	case "json":
+		content, err := json.MarshalIndent(value, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(content))
+		return err
+	case "yaml":
+		// Going through JSON keeps the field names the API uses.
+		content, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		var generic interface{}
+		if err := json.Unmarshal(content, &generic); err != nil {
+			return err
+		}
+
+		if content, err = yaml.Marshal(generic); err != nil {
+			return err
+		}
+		_, err = p.out.Write(content)
+		return err
+	case "table":
+		w := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown output format: %s (expected table, json or yaml)", p.format)
+	}
+}
+
+func racesTable(races []heroes.Race) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tSTR\tAGI\tINT\tWIL")
+		for _, race := range races {
+			a := race.BaseAttributes
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%d\n", race.ID, race.Name, a.Strength, a.Agility, a.Intelligence, a.Willpower)
+		}
+	}
+}
+
+func classesTable(classes []heroes.Class) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tROLE\tSTR\tAGI\tINT\tWIL")
+		for _, class := range classes {
+			a := class.BonusAttributes
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%d\n", class.ID, class.Name, class.Role, a.Strength, a.Agility, a.Intelligence, a.Willpower)
+		}
+	}
+}
+
+func skillsTable(skills []heroes.Skill) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tTYPE\tSOURCE\tLEVEL\tACTIVATION\tDIFFICULTY\tMANA")
+		for _, skill := range skills {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", skill.ID, skill.Name, skill.Type, skill.Source,
+				skill.LevelRequirement, skill.Activation, difficulty(skill), skill.Mana)
+		}
+	}
+}
+
+func proficienciesTable(proficiencies []heroes.Proficiency) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME")
+		for _, proficiency := range proficiencies {
+			fmt.Fprintf(w, "%d\t%s\n", proficiency.ID, proficiency.Name)
+		}
+	}
+}
+
+func searchTable(results []client.SearchResult) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "TYPE\tID\tNAME\tDESCRIPTION")
+		for _, result := range results {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", result.Type, result.ID, result.Name, result.Description)
+		}
+	}
+}
+
+// importTable lists every entry created, updated or conflicting, leaving unchanged ones as a count.
+func importTable(report client.ImportReport) func(io.Writer) {
+	return func(w io.Writer) {
+		if report.DryRun {
+			fmt.Fprintln(w, "Dry run, nothing was written.")
+		}
+
+		fmt.Fprintln(w, "ACTION\tENTITY\tID\tKEY\tMESSAGE")
+		for _, group := range []struct {
+			action  string
+			entries []client.ImportEntry
+		}{{"created", report.Created}, {"updated", report.Updated}, {"conflict", report.Conflicts}} {
+			for _, entry := range group.entries {
+				id := ""
+				if entry.ID > 0 {
+					id = fmt.Sprint(entry.ID)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", group.action, entry.Entity, id, entry.Key, entry.Message)
+			}
+		}
+		fmt.Fprintf(w, "%d unchanged.\n", len(report.Unchanged))
+	}
+}
+
+func raceDetails(race heroes.Race) func(io.Writer) {
+	return func(w io.Writer) {
+		a := race.BaseAttributes
+		fmt.Fprintf(w, "ID:\t%d\n", race.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", race.Name)
+		fmt.Fprintf(w, "Description:\t%s\n", race.Description)
+		fmt.Fprintf(w, "Attributes:\tSTR %d, AGI %d, INT %d, WIL %d\n", a.Strength, a.Agility, a.Intelligence, a.Willpower)
+		fmt.Fprintf(w, "Starting skills:\t%s\n", skillNames(race.StartingSkills))
+		fmt.Fprintf(w, "Available skills:\t%s\n", skillNames(race.AvailableSkills))
+		fmt.Fprintf(w, "Recommended classes:\t%s\n", classNames(race.RecommendedClasses))
+		fmt.Fprintf(w, "Version:\t%d\n", race.Version)
+	}
+}
+
+func classDetails(class heroes.Class) func(io.Writer) {
+	return func(w io.Writer) {
+		a := class.BonusAttributes
+		proficiencies := make([]string, len(class.Proficiencies))
+		for i, proficiency := range class.Proficiencies {
+			proficiencies[i] = string(proficiency.Name)
+		}
+
+		fmt.Fprintf(w, "ID:\t%d\n", class.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", class.Name)
+		fmt.Fprintf(w, "Role:\t%s\n", class.Role)
+		fmt.Fprintf(w, "Description:\t%s\n", class.Description)
+		fmt.Fprintf(w, "Attributes:\tSTR %d, AGI %d, INT %d, WIL %d\n", a.Strength, a.Agility, a.Intelligence, a.Willpower)
+		fmt.Fprintf(w, "Proficiencies:\t%s\n", strings.Join(proficiencies, ", "))
+		fmt.Fprintf(w, "Starting skills:\t%s\n", skillNames(class.StartingSkills))
+		fmt.Fprintf(w, "Available skills:\t%s\n", skillNames(class.AvailableSkills))
+		fmt.Fprintf(w, "Version:\t%d\n", class.Version)
+	}
+}
+
+func skillDetails(skill heroes.Skill) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%d\n", skill.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", skill.Name)
+		fmt.Fprintf(w, "Type:\t%s\n", skill.Type)
+		fmt.Fprintf(w, "Source:\t%s\n", skill.Source)
+		fmt.Fprintf(w, "Level:\t%s\n", skill.LevelRequirement)
+		fmt.Fprintf(w, "Activation:\t%s\n", skill.Activation)
+		fmt.Fprintf(w, "Difficulty:\t%s\n", difficulty(skill))
+		fmt.Fprintf(w, "Mana:\t%s\n", skill.Mana)
+		fmt.Fprintf(w, "Bonus:\t%s\n", skill.Bonus)
+		fmt.Fprintf(w, "Description:\t%s\n", skill.Description)
+		fmt.Fprintf(w, "Observations:\t%s\n", skill.Observations)
+		fmt.Fprintf(w, "Requirements:\t%s\n", skillNames(skill.SkillRequirements))
+		fmt.Fprintf(w, "Version:\t%d\n", skill.Version)
+	}
+}
+
+// skillTree draws the skill along with every requirement it depends on, recursively.
+func skillTree(skill heroes.Skill) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintf(w, "%s (#%d)\n", skill.Name, skill.ID)
+		drawRequirements(w, skill.SkillRequirements, "")
+	}
+}
+
+func drawRequirements(w io.Writer, requirements []heroes.Skill, prefix string) {
+	for i, requirement := range requirements {
+		branch, indent := "├── ", "│   "
+		if i == len(requirements)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		fmt.Fprintf(w, "%s%s%s (#%d)\n", prefix, branch, requirement.Name, requirement.ID)
+		drawRequirements(w, requirement.SkillRequirements, prefix+indent)
+	}
+}
+
+func difficulty(skill heroes.Skill) string {
+	if skill.Difficulty == "" {
+		return string(skill.DifficultyType)
+	}
+
+	return fmt.Sprintf("%s %s", skill.DifficultyType, skill.Difficulty)
+}
+
+func skillNames(skills []heroes.Skill) string {
+	names := make([]string, len(skills))
+	for i, skill := range skills {
+		names[i] = skill.Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func classNames(classes []heroes.Class) string {
+	names := make([]string, len(classes))
+	for i, class := range classes {
+		names[i] = class.Name
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AuditEntry records who changed an entity, when, and what it looked like before and after the change.
+type AuditEntry struct {
+	ID         uint64          `json:"id"`
+	Actor      string          `json:"actor"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint64          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Diff       json.RawMessage `json:"diff"`
+}
+
+// AuditQuery filters audit entries. Zero values match everything.
+type AuditQuery struct {
+	EntityType string
+	EntityID   uint64
+	Actor      string
+	Action     string
+	Since      time.Time
+	Until      time.Time
+	Page       Page
+}
+
+func (q AuditQuery) values() url.Values {
+	query := url.Values{}
+	for name, value := range map[string]string{"entity_type": q.EntityType, "actor": q.Actor, "action": q.Action} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	if q.EntityID > 0 {
+		query.Set("entity_id", strconv.FormatUint(q.EntityID, 10))
+	}
+
+	if !q.Since.IsZero() {
+		query.Set("since", q.Since.Format(time.RFC3339))
+	}
+
+	if !q.Until.IsZero() {
+		query.Set("until", q.Until.Format(time.RFC3339))
+	}
+
+	q.Page.apply(query)
+	return query
+}
+
+// ListAudit lists a page of audit entries, latest first. Requires an administrator token.
+func (c *Client) ListAudit(ctx context.Context, query AuditQuery) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	return entries, c.do(ctx, request{method: http.MethodGet, path: "/audit", query: query.values()}, &entries)
+}
+
+// EachAudit calls fn with every audit entry matching the query, latest first, fetching one page at a time.
+// Iteration stops at the first error returned by fn. Requires an administrator token.
+func (c *Client) EachAudit(ctx context.Context, query AuditQuery, fn func(AuditEntry) error) error {
+	if query.Page.Limit <= 0 {
+		query.Page.Limit = 100
+	}
+
+	for {
+		entries, err := c.ListAudit(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+
+		if len(entries) < query.Page.Limit {
+			return nil
+		}
+		query.Page.Offset += len(entries)
+	}
+}
+
+// GetHistory lists a page of audit entries of a single entity, oldest first. Resource is the collection name, such
+// as "races". Requires an administrator token.
+func (c *Client) GetHistory(ctx context.Context, resource string, id uint64, page Page) ([]AuditEntry, error) {
+	query := url.Values{}
+	page.apply(query)
+
+	var entries []AuditEntry
+	path := fmt.Sprintf("/%s/%d/history", url.PathEscape(resource), id)
+	return entries, c.do(ctx, request{method: http.MethodGet, path: path, query: query}, &entries)
+}
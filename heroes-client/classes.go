@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// ListClasses lists every class, without associations.
+func (c *Client) ListClasses(ctx context.Context, options ...ListOption) ([]heroes.Class, error) {
+	var classes []heroes.Class
+	return classes, c.do(ctx, request{method: http.MethodGet, path: "/classes", query: listQuery(options)}, &classes)
+}
+
+// GetClass finds the class with the provided ID, along with its associations.
+func (c *Client) GetClass(ctx context.Context, id uint64, options ...ListOption) (heroes.Class, error) {
+	var class heroes.Class
+	return class, c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/classes/%d", id), query: listQuery(options)}, &class)
+}
+
+// GetClassByName finds the class by name or slug, ignoring case and accents. When none matches, the ErrNotFound error
+// message suggests similar names.
+func (c *Client) GetClassByName(ctx context.Context, name string, options ...ListOption) (heroes.Class, error) {
+	var class heroes.Class
+	return class, c.do(ctx, request{method: http.MethodGet, path: "/classes/by-name/" + url.PathEscape(name), query: listQuery(options)}, &class)
+}
+
+// GetClassBySlug finds the class with the provided slug, such as "dark-knight", along with its associations.
+func (c *Client) GetClassBySlug(ctx context.Context, slug string, options ...ListOption) (heroes.Class, error) {
+	var class heroes.Class
+	return class, c.do(ctx, request{method: http.MethodGet, path: "/classes/" + url.PathEscape(slug), query: listQuery(options)}, &class)
+}
+
+// GetClasses finds the classes with the provided IDs in a single request, in the same order, along with the IDs not found.
+func (c *Client) GetClasses(ctx context.Context, ids []uint64, options ...ListOption) ([]heroes.Class, []uint64, error) {
+	var batch struct {
+		Results []heroes.Class `json:"results"`
+		Missing []uint64       `json:"missing"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/classes/batch", query: listQuery(options), body: map[string][]uint64{"ids": ids}}, &batch)
+	return batch.Results, batch.Missing, err
+}
+
+// ListClassesByRole lists classes of the provided role.
+func (c *Client) ListClassesByRole(ctx context.Context, role heroes.Role, options ...ListOption) ([]heroes.Class, error) {
+	var classes []heroes.Class
+	path := "/classes/by-role/" + url.PathEscape(string(role))
+	return classes, c.do(ctx, request{method: http.MethodGet, path: path, query: listQuery(options)}, &classes)
+}
+
+// ListClassesByProficiencies lists classes having any, all or none of the provided proficiencies, as chosen by match.
+func (c *Client) ListClassesByProficiencies(ctx context.Context, match heroes.Match, proficiencies ...heroes.ProficiencyType) ([]heroes.Class, error) {
+	query := url.Values{"match": {string(match)}}
+	for _, proficiency := range proficiencies {
+		query.Add("proficiencies", string(proficiency))
+	}
+
+	var classes []heroes.Class
+	return classes, c.do(ctx, request{method: http.MethodGet, path: "/classes/by-proficiencies", query: query}, &classes)
+}
+
+// CreateClass creates the provided class, returning it as stored.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) CreateClass(ctx context.Context, class heroes.Class) (heroes.Class, error) {
+	var created heroes.Class
+	return created, c.do(ctx, request{method: http.MethodPost, path: "/classes", body: class}, &created)
+}
+
+// UpdateClass replaces every field of the class, as long as it is still at class.Version.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) UpdateClass(ctx context.Context, class heroes.Class) (heroes.Class, error) {
+	var updated heroes.Class
+	return updated, c.do(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/classes/%d", class.ID), body: class, version: class.Version}, &updated)
+}
+
+// PatchClass changes only the provided fields, keyed by their JSON names, as long as the class is still at version.
+func (c *Client) PatchClass(ctx context.Context, id uint64, version uint64, fields map[string]interface{}) (heroes.Class, error) {
+	var patched heroes.Class
+	return patched, c.do(ctx, request{method: http.MethodPatch, path: fmt.Sprintf("/classes/%d", id), body: fields, version: version}, &patched)
+}
+
+// DeleteClass soft deletes the class, as long as it is still at version.
+func (c *Client) DeleteClass(ctx context.Context, id uint64, version uint64) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/classes/%d", id), version: version}, nil)
+}
+
+// RestoreClass brings back a soft deleted class.
+func (c *Client) RestoreClass(ctx context.Context, id uint64) (heroes.Class, error) {
+	var restored heroes.Class
+	return restored, c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/classes/%d/restore", id)}, &restored)
+}
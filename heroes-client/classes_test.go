@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+func Test_Client_UpdateClass_MISMATCH(t *testing.T) {
+	var received []*http.Request
+	server := fakeAPI(http.StatusPreconditionFailed, `"Resource was changed since version 2."`, &received)
+	defer server.Close()
+
+	c, _ := New(server.URL, WithToken("secret"))
+	class := heroes.Class{Name: "Barbarian", Role: heroes.Fighter}
+	class.ID, class.Version = 1, 2
+	if _, err := c.UpdateClass(context.Background(), class); !errors.Is(err, ErrVersionMismatch) {
+		t.Error("Expected a version mismatch, found:", err)
+	}
+
+	if r := received[0]; r.Method != http.MethodPut || r.URL.Path != "/classes/1" || r.Header.Get("If-Match") != `"2"` {
+		t.Error("Invalid request sent:", r.Method, r.URL, r.Header)
+	}
+}
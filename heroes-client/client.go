@@ -0,0 +1,207 @@
+// Package client is the Go SDK of the heroes API. It returns the same heroes types the API is built upon.
+//
+//	c, err := client.New("http://localhost:8080")
+//	races, err := c.ListRacesByRecommendedClasses(ctx, heroes.MatchAny, "wizard")
+//
+// Failed reads and idempotent writes are retried with exponential backoff, and every call honours its context.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the heroes API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set timeouts or transports.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken authenticates every request as the administrator owning the token, as required by writes.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithRetry changes how many times a request is attempted, and the backoff between attempts.
+// The delay starts at baseDelay and doubles on every attempt, up to maxDelay. One attempt disables retries.
+func WithRetry(maxAttempts int, baseDelay time.Duration, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseDelay = baseDelay
+		c.maxDelay = maxDelay
+	}
+}
+
+// New constructs a Client for the API at baseURL, such as "http://localhost:8080".
+// By default, requests are attempted 3 times, waiting 100ms then 200ms in between.
+func New(baseURL string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:     parsed,
+		httpClient:  http.DefaultClient,
+		maxAttempts: 3,
+		baseDelay:   100 * time.Millisecond,
+		maxDelay:    5 * time.Second,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c, nil
+}
+
+// request describes a single API call.
+// Paths are escaped already, such as by url.PathEscape. Bodies are sent as JSON, unless they are already encoded as
+// []byte in contentType.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        interface{}
+	contentType string
+	version     uint64
+}
+
+// do sends the request, retrying failures when it is safe to do so, and decodes the response into dest when not nil.
+// Responses are kept as they are when dest is a *[]byte.
+func (c *Client) do(ctx context.Context, r request, dest interface{}) error {
+	var body []byte
+	if raw, ok := r.body.([]byte); ok {
+		body = raw
+	} else if r.body != nil {
+		var err error
+		if body, err = json.Marshal(r.body); err != nil {
+			return err
+		}
+		r.contentType = "application/json"
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = c.attempt(ctx, r, body, dest); !retry || attempt >= c.maxAttempts || !idempotent(r.method) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+// attempt sends the request once, telling whether a failure is worth retrying.
+func (c *Client) attempt(ctx context.Context, r request, body []byte, dest interface{}) (bool, error) {
+	endpoint := *c.baseURL
+	endpoint.RawPath = endpoint.EscapedPath() + r.path
+	path, err := url.PathUnescape(endpoint.RawPath)
+	if err != nil {
+		return false, err
+	}
+	endpoint.Path = path
+	endpoint.RawQuery = r.query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, r.method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	if _, raw := dest.(*[]byte); raw {
+		req.Header.Set("Accept", "*/*")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	if r.version > 0 {
+		req.Header.Set("If-Match", strconv.Quote(strconv.FormatUint(r.version, 10)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Network failures are retried, unless the caller gave up.
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return retry, newAPIError(resp.StatusCode, payload)
+	}
+
+	if dest == nil || len(payload) == 0 {
+		return false, nil
+	}
+
+	if raw, ok := dest.(*[]byte); ok {
+		*raw = payload
+		return false, nil
+	}
+
+	if err := json.Unmarshal(payload, dest); err != nil {
+		return false, fmt.Errorf("heroes: invalid response from %s %s: %w", r.method, r.path, err)
+	}
+
+	return false, nil
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.baseDelay
+	for i := 1; i < attempt && delay < c.maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > c.maxDelay {
+		delay = c.maxDelay
+	}
+
+	return delay
+}
+
+// idempotent requests can be safely sent again after a failure.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// fakeAPI answers every request with status and body, recording the requests received.
+func fakeAPI(status int, body string, received *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if received != nil {
+			*received = append(*received, r)
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func Test_Client_NOTFOUND(t *testing.T) {
+	server := fakeAPI(http.StatusNotFound, `"{id: 1000, message: \"Resource not found.\"}"`, nil)
+	defer server.Close()
+
+	c, _ := New(server.URL)
+	_, err := c.GetRace(context.Background(), 1000)
+
+	var apiErr *APIError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) || apiErr.Message == "" {
+		t.Error("Expected a not found error, found:", err)
+	}
+}
+
+func Test_Client_ESCAPE(t *testing.T) {
+	var received []*http.Request
+	server := fakeAPI(http.StatusOK, `{"id": 1, "name": "Dark Knight"}`, &received)
+	defer server.Close()
+
+	c, _ := New(server.URL)
+	c.GetClassByName(context.Background(), "Dark Knight/Paladin?")
+	c.GetRaceBySlug(context.Background(), "high-elf")
+	c.GetSkillBySlug(context.Background(), "magic missile")
+
+	var paths []string
+	for _, r := range received {
+		paths = append(paths, r.URL.EscapedPath())
+	}
+
+	expected := []string{"/classes/by-name/Dark%20Knight%2FPaladin%3F", "/races/high-elf", "/skills/magic%20missile"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("Expected the paths %v, got: %v", expected, paths)
+	}
+}
+
+func Test_Client_RETRY(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`[{"id": 1, "name": "Barbarian", "role": "fighter"}]`))
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetry(3, time.Millisecond, time.Millisecond))
+	classes, err := c.ListClassesByRole(context.Background(), heroes.Fighter)
+	if err != nil || len(classes) != 1 || attempts != 3 {
+		t.Error("Expected a successful third attempt, found:", classes, err, attempts)
+	}
+
+	c, _ = New(server.URL, WithRetry(1, time.Millisecond, time.Millisecond))
+	attempts = 0
+	if _, err := c.ListClasses(context.Background()); !errors.Is(err, ErrUnavailable) || attempts != 1 {
+		t.Error("Expected a single unavailable attempt, found:", err, attempts)
+	}
+}
+
+func Test_Client_CANCELED(t *testing.T) {
+	server := fakeAPI(http.StatusInternalServerError, "", nil)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c, _ := New(server.URL, WithRetry(100, time.Second, time.Second))
+	if _, err := c.ListSkills(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected the context deadline to stop retries, found:", err)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ImportReport tells what an import did, or would do when DryRun.
+type ImportReport struct {
+	DryRun    bool          `json:"dry_run"`
+	Created   []ImportEntry `json:"created"`
+	Updated   []ImportEntry `json:"updated"`
+	Unchanged []ImportEntry `json:"unchanged"`
+	Conflicts []ImportEntry `json:"conflicts"`
+}
+
+// ImportEntry is a single record of an import. Entity is its table name, such as "races", and Key its slug, or its
+// type for proficiencies. Conflicts explain what is wrong in Message.
+type ImportEntry struct {
+	Entity  string `json:"entity"`
+	ID      uint64 `json:"id,omitempty"`
+	Key     string `json:"key"`
+	Message string `json:"message,omitempty"`
+}
+
+// ExportCompendium downloads every race, class, skill and proficiency as json, yaml or csv. Entity narrows the export
+// to a single entity type, such as "skills", and is required by csv.
+func (c *Client) ExportCompendium(ctx context.Context, format string, entity string) ([]byte, error) {
+	var content []byte
+	return content, c.do(ctx, request{method: http.MethodGet, path: "/export", query: compendiumQuery(format, entity)}, &content)
+}
+
+// ImportCompendium creates and updates entities from content, written in any format ExportCompendium downloads, all at
+// once or not at all. With dryRun nothing is written. When there are conflicts, the report comes along with ErrConflict.
+func (c *Client) ImportCompendium(ctx context.Context, content []byte, format string, entity string, dryRun bool) (ImportReport, error) {
+	query := compendiumQuery(format, entity)
+	query.Set("dry_run", strconv.FormatBool(dryRun))
+
+	var report ImportReport
+	err := c.do(ctx, request{method: http.MethodPost, path: "/import", query: query, body: content, contentType: contentTypes[format]}, &report)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		// Conflicts are described by the report itself, sent instead of a message.
+		if json.Unmarshal([]byte(apiErr.Message), &report) == nil {
+			err = &APIError{StatusCode: apiErr.StatusCode, Message: strconv.Itoa(len(report.Conflicts)) + " conflicts, nothing was imported"}
+		}
+	}
+
+	return report, err
+}
+
+var contentTypes = map[string]string{
+	"":     "application/json",
+	"json": "application/json",
+	"yaml": "application/yaml",
+	"csv":  "text/csv",
+}
+
+func compendiumQuery(format string, entity string) url.Values {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+
+	if entity != "" {
+		query.Set("entity", entity)
+	}
+
+	return query
+}
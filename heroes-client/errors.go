@@ -0,0 +1,60 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors matching the API responses, to be checked with errors.Is.
+var (
+	ErrBadRequest           = errors.New("heroes: invalid request")
+	ErrUnauthorized         = errors.New("heroes: administrator token required")
+	ErrNotFound             = errors.New("heroes: resource not found")
+	ErrVersionMismatch      = errors.New("heroes: resource was changed by someone else")
+	ErrPreconditionRequired = errors.New("heroes: resource version required")
+	ErrConflict             = errors.New("heroes: conflicting resources")
+	ErrUnavailable          = errors.New("heroes: service unavailable")
+)
+
+// APIError is returned whenever the API answers with an unsuccessful status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error describes the status along with the message sent by the API.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("heroes: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is matches the APIError with the sentinel error of its status.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusPreconditionFailed:
+		return target == ErrVersionMismatch
+	case http.StatusPreconditionRequired:
+		return target == ErrPreconditionRequired
+	case http.StatusConflict:
+		return target == ErrConflict
+	default:
+		return e.StatusCode >= http.StatusInternalServerError && target == ErrUnavailable
+	}
+}
+
+// newAPIError reads the message from the response body. The API sends messages as JSON strings.
+func newAPIError(statusCode int, body []byte) *APIError {
+	var message string
+	if err := json.Unmarshal(body, &message); err != nil {
+		message = string(body)
+	}
+
+	return &APIError{StatusCode: statusCode, Message: message}
+}
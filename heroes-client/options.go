@@ -0,0 +1,61 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListOption customizes list calls.
+type ListOption func(query url.Values)
+
+// IncludeDeleted also lists soft deleted resources. Requires an administrator token.
+func IncludeDeleted() ListOption {
+	return func(query url.Values) { query.Set("include_deleted", "true") }
+}
+
+// Filter narrows list results with an expression, such as "type eq spell and level_requirement in (advanced, master)".
+func Filter(expression string) ListOption {
+	return func(query url.Values) { query.Set("filter", expression) }
+}
+
+// Fields returns only the provided JSON fields, such as "name" or "starting_skills.name". Others are left empty.
+func Fields(names ...string) ListOption {
+	return func(query url.Values) { query.Set("fields", strings.Join(names, ",")) }
+}
+
+// Expand includes the provided associations, such as "recommended_classes.proficiencies". Without it, single
+// resources include their direct associations and lists include none.
+func Expand(associations ...string) ListOption {
+	return func(query url.Values) { query.Set("expand", strings.Join(associations, ",")) }
+}
+
+// Paginate lists at most limit results, ordered by ID, after skipping offset of them. Without it, lists hold every result.
+func Paginate(limit int, offset int) ListOption {
+	return Page{Limit: limit, Offset: offset}.apply
+}
+
+// Page selects a page of the routes that paginate, such as lists and the audit log. Zero values use the API defaults.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+func (p Page) apply(query url.Values) {
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+
+	if p.Offset > 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+}
+
+func listQuery(options []ListOption) url.Values {
+	query := url.Values{}
+	for _, option := range options {
+		option(query)
+	}
+
+	return query
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// ListProficiencies lists every proficiency.
+func (c *Client) ListProficiencies(ctx context.Context, options ...ListOption) ([]heroes.Proficiency, error) {
+	var proficiencies []heroes.Proficiency
+	return proficiencies, c.do(ctx, request{method: http.MethodGet, path: "/proficiencies", query: listQuery(options)}, &proficiencies)
+}
+
+// GetProficiency finds the proficiency with the provided ID.
+func (c *Client) GetProficiency(ctx context.Context, id uint64, options ...ListOption) (heroes.Proficiency, error) {
+	var proficiency heroes.Proficiency
+	return proficiency, c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/proficiencies/%d", id), query: listQuery(options)}, &proficiency)
+}
+
+// ListProficiencyClasses lists classes having the proficiency with the provided ID.
+func (c *Client) ListProficiencyClasses(ctx context.Context, id uint64, options ...ListOption) ([]heroes.Class, error) {
+	var classes []heroes.Class
+	return classes, c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/proficiencies/%d/classes", id), query: listQuery(options)}, &classes)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// ListRaces lists every race, without associations.
+func (c *Client) ListRaces(ctx context.Context, options ...ListOption) ([]heroes.Race, error) {
+	var races []heroes.Race
+	return races, c.do(ctx, request{method: http.MethodGet, path: "/races", query: listQuery(options)}, &races)
+}
+
+// GetRace finds the race with the provided ID, along with its associations.
+func (c *Client) GetRace(ctx context.Context, id uint64, options ...ListOption) (heroes.Race, error) {
+	var race heroes.Race
+	return race, c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/races/%d", id), query: listQuery(options)}, &race)
+}
+
+// GetRaceByName finds the race by name or slug, ignoring case and accents. When none matches, the ErrNotFound error
+// message suggests similar names.
+func (c *Client) GetRaceByName(ctx context.Context, name string, options ...ListOption) (heroes.Race, error) {
+	var race heroes.Race
+	return race, c.do(ctx, request{method: http.MethodGet, path: "/races/by-name/" + url.PathEscape(name), query: listQuery(options)}, &race)
+}
+
+// GetRaceBySlug finds the race with the provided slug, such as "high-elf", along with its associations.
+func (c *Client) GetRaceBySlug(ctx context.Context, slug string, options ...ListOption) (heroes.Race, error) {
+	var race heroes.Race
+	return race, c.do(ctx, request{method: http.MethodGet, path: "/races/" + url.PathEscape(slug), query: listQuery(options)}, &race)
+}
+
+// GetRaces finds the races with the provided IDs in a single request, in the same order, along with the IDs not found.
+func (c *Client) GetRaces(ctx context.Context, ids []uint64, options ...ListOption) ([]heroes.Race, []uint64, error) {
+	var batch struct {
+		Results []heroes.Race `json:"results"`
+		Missing []uint64      `json:"missing"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/races/batch", query: listQuery(options), body: map[string][]uint64{"ids": ids}}, &batch)
+	return batch.Results, batch.Missing, err
+}
+
+// ListRacesByRecommendedClasses lists races recommending any, all or none of the provided class names, as chosen by match.
+func (c *Client) ListRacesByRecommendedClasses(ctx context.Context, match heroes.Match, classes ...string) ([]heroes.Race, error) {
+	query := url.Values{"classes": classes, "match": {string(match)}}
+
+	var races []heroes.Race
+	return races, c.do(ctx, request{method: http.MethodGet, path: "/races/by-recommended-classes", query: query}, &races)
+}
+
+// CreateRace creates the provided race, returning it as stored.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) CreateRace(ctx context.Context, race heroes.Race) (heroes.Race, error) {
+	var created heroes.Race
+	return created, c.do(ctx, request{method: http.MethodPost, path: "/races", body: race}, &created)
+}
+
+// UpdateRace replaces every field of the race, as long as it is still at race.Version.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) UpdateRace(ctx context.Context, race heroes.Race) (heroes.Race, error) {
+	var updated heroes.Race
+	return updated, c.do(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/races/%d", race.ID), body: race, version: race.Version}, &updated)
+}
+
+// PatchRace changes only the provided fields, keyed by their JSON names, as long as the race is still at version.
+func (c *Client) PatchRace(ctx context.Context, id uint64, version uint64, fields map[string]interface{}) (heroes.Race, error) {
+	var patched heroes.Race
+	return patched, c.do(ctx, request{method: http.MethodPatch, path: fmt.Sprintf("/races/%d", id), body: fields, version: version}, &patched)
+}
+
+// DeleteRace soft deletes the race, as long as it is still at version.
+func (c *Client) DeleteRace(ctx context.Context, id uint64, version uint64) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/races/%d", id), version: version}, nil)
+}
+
+// RestoreRace brings back a soft deleted race.
+func (c *Client) RestoreRace(ctx context.Context, id uint64) (heroes.Race, error) {
+	var restored heroes.Race
+	return restored, c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/races/%d/restore", id)}, &restored)
+}
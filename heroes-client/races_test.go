@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func Test_Client_ListRaces_PAGINATE(t *testing.T) {
+	var received []*http.Request
+	server := fakeAPI(http.StatusOK, `[{"id": 5, "name": "Goblin"}]`, &received)
+	defer server.Close()
+
+	c, _ := New(server.URL)
+	races, err := c.ListRaces(context.Background(), Paginate(2, 4), Filter("name ne Elf"))
+	if err != nil || len(races) != 1 {
+		t.Error("Invalid races found:", races, err)
+	}
+
+	if query := received[0].URL.Query(); query.Get("limit") != "2" || query.Get("offset") != "4" || query.Get("filter") != "name ne Elf" {
+		t.Error("Invalid query sent:", query)
+	}
+}
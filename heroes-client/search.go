@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SearchResult is a race, class or skill matching a search. Type is its table name, such as "races".
+type SearchResult struct {
+	Type        string  `json:"type"`
+	ID          uint64  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Rank        float64 `json:"rank"`
+}
+
+// Search races, classes and skills by words of their names, descriptions, bonuses and observations, best matches first.
+func (c *Client) Search(ctx context.Context, text string, page Page) ([]SearchResult, error) {
+	query := url.Values{"q": {text}}
+	page.apply(query)
+
+	var results []SearchResult
+	return results, c.do(ctx, request{method: http.MethodGet, path: "/search", query: query}, &results)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// ListSkills lists every skill, without requirements.
+func (c *Client) ListSkills(ctx context.Context, options ...ListOption) ([]heroes.Skill, error) {
+	var skills []heroes.Skill
+	return skills, c.do(ctx, request{method: http.MethodGet, path: "/skills", query: listQuery(options)}, &skills)
+}
+
+// GetSkill finds the skill with the provided ID, along with its requirements.
+func (c *Client) GetSkill(ctx context.Context, id uint64, options ...ListOption) (heroes.Skill, error) {
+	var skill heroes.Skill
+	return skill, c.do(ctx, request{method: http.MethodGet, path: fmt.Sprintf("/skills/%d", id), query: listQuery(options)}, &skill)
+}
+
+// GetSkillByName finds the skill by name or slug, ignoring case and accents. When none matches, the ErrNotFound error
+// message suggests similar names.
+func (c *Client) GetSkillByName(ctx context.Context, name string, options ...ListOption) (heroes.Skill, error) {
+	var skill heroes.Skill
+	return skill, c.do(ctx, request{method: http.MethodGet, path: "/skills/by-name/" + url.PathEscape(name), query: listQuery(options)}, &skill)
+}
+
+// GetSkillBySlug finds the skill with the provided slug, such as "magic-missile", along with its requirements.
+func (c *Client) GetSkillBySlug(ctx context.Context, slug string, options ...ListOption) (heroes.Skill, error) {
+	var skill heroes.Skill
+	return skill, c.do(ctx, request{method: http.MethodGet, path: "/skills/" + url.PathEscape(slug), query: listQuery(options)}, &skill)
+}
+
+// GetSkills finds the skills with the provided IDs in a single request, in the same order, along with the IDs not found.
+func (c *Client) GetSkills(ctx context.Context, ids []uint64, options ...ListOption) ([]heroes.Skill, []uint64, error) {
+	var batch struct {
+		Results []heroes.Skill `json:"results"`
+		Missing []uint64       `json:"missing"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/skills/batch", query: listQuery(options), body: map[string][]uint64{"ids": ids}}, &batch)
+	return batch.Results, batch.Missing, err
+}
+
+// ListSkillsByType lists skills of the provided type.
+func (c *Client) ListSkillsByType(ctx context.Context, skillType heroes.SkillType, options ...ListOption) ([]heroes.Skill, error) {
+	var skills []heroes.Skill
+	path := "/skills/by-type/" + url.PathEscape(string(skillType))
+	return skills, c.do(ctx, request{method: http.MethodGet, path: path, query: listQuery(options)}, &skills)
+}
+
+// ListSkillsBySource lists skills learnt from the provided source.
+func (c *Client) ListSkillsBySource(ctx context.Context, source heroes.Source, options ...ListOption) ([]heroes.Skill, error) {
+	var skills []heroes.Skill
+	path := "/skills/by-source/" + url.PathEscape(string(source))
+	return skills, c.do(ctx, request{method: http.MethodGet, path: path, query: listQuery(options)}, &skills)
+}
+
+// CreateSkill creates the provided skill, returning it as stored.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) CreateSkill(ctx context.Context, skill heroes.Skill) (heroes.Skill, error) {
+	var created heroes.Skill
+	return created, c.do(ctx, request{method: http.MethodPost, path: "/skills", body: skill}, &created)
+}
+
+// UpdateSkill replaces every field of the skill, as long as it is still at skill.Version.
+// Its associations must be empty, as the API rejects them.
+func (c *Client) UpdateSkill(ctx context.Context, skill heroes.Skill) (heroes.Skill, error) {
+	var updated heroes.Skill
+	return updated, c.do(ctx, request{method: http.MethodPut, path: fmt.Sprintf("/skills/%d", skill.ID), body: skill, version: skill.Version}, &updated)
+}
+
+// PatchSkill changes only the provided fields, keyed by their JSON names, as long as the skill is still at version.
+func (c *Client) PatchSkill(ctx context.Context, id uint64, version uint64, fields map[string]interface{}) (heroes.Skill, error) {
+	var patched heroes.Skill
+	return patched, c.do(ctx, request{method: http.MethodPatch, path: fmt.Sprintf("/skills/%d", id), body: fields, version: version}, &patched)
+}
+
+// DeleteSkill soft deletes the skill, as long as it is still at version.
+func (c *Client) DeleteSkill(ctx context.Context, id uint64, version uint64) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: fmt.Sprintf("/skills/%d", id), version: version}, nil)
+}
+
+// RestoreSkill brings back a soft deleted skill.
+func (c *Client) RestoreSkill(ctx context.Context, id uint64) (heroes.Skill, error) {
+	var restored heroes.Skill
+	return restored, c.do(ctx, request{method: http.MethodPost, path: fmt.Sprintf("/skills/%d/restore", id)}, &restored)
+}
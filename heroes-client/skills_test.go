@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+func Test_Client_GetSkill_OK(t *testing.T) {
+	var received []*http.Request
+	server := fakeAPI(http.StatusOK, `{"id": 2, "name": "War Cry", "type": "ability"}`, &received)
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skill, err := c.GetSkill(context.Background(), 2)
+	if err != nil || skill.Name != "War Cry" || skill.Type != heroes.Ability {
+		t.Error("Invalid skill found:", skill, err)
+	}
+
+	if len(received) != 1 || received[0].URL.Path != "/skills/2" {
+		t.Error("Invalid request sent:", received)
+	}
+}
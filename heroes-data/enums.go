@@ -0,0 +1,187 @@
+package heroes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EnumValue describes one allowed value of an enum, with a human readable label.
+type EnumValue struct {
+	Value       string `json:"value"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+}
+
+// Enum lists every allowed value of an enum type, such as Role or SkillType.
+type Enum struct {
+	Name   string      `json:"name"`
+	Values []EnumValue `json:"values"`
+}
+
+// Enums describes every enum of the heroes package. This is the source of truth clients should read allowed values from.
+func Enums() []Enum {
+	enums := []Enum{proficiencyTypes, roles, difficultyTypes, activations, sources, skillTypes, levelRequirements}
+	for i := range enums {
+		enums[i].Values = append([]EnumValue(nil), enums[i].Values...)
+	}
+
+	return enums
+}
+
+// Values lists the allowed values of the enum type T, such as Role, found among Enums by its type name.
+func Values[T ~string]() []T {
+	name := reflect.TypeOf(T("")).Name()
+	for _, enum := range Enums() {
+		if enum.Name != name {
+			continue
+		}
+
+		values := make([]T, len(enum.Values))
+		for i, value := range enum.Values {
+			values[i] = T(value.Value)
+		}
+		return values
+	}
+
+	return nil
+}
+
+// Allowed lists the values of the enum.
+func (e Enum) Allowed() []string {
+	allowed := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		allowed[i] = value.Value
+	}
+
+	return allowed
+}
+
+func (e Enum) contains(value string) bool {
+	for _, allowed := range e.Values {
+		if allowed.Value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidEnumError is returned when parsing a value that doesn't belong to its enum.
+type InvalidEnumError struct {
+	Enum    string
+	Value   string
+	Allowed []string
+}
+
+func (e *InvalidEnumError) Error() string {
+	return fmt.Sprintf("%s should be one of %s. Invalid value received: %q", e.Enum, strings.Join(e.Allowed, ", "), e.Value)
+}
+
+// parse matches the value against the enum, ignoring case.
+func parse[T ~string](e Enum, value string) (T, error) {
+	if normalized := strings.ToLower(strings.TrimSpace(value)); e.contains(normalized) {
+		return T(normalized), nil
+	}
+
+	return "", &InvalidEnumError{e.Name, value, e.Allowed()}
+}
+
+// scan reads an enum column. NULL and empty columns are the unset zero value, anything else must be allowed.
+func scan[T ~string](e Enum, dest *T, src interface{}) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("unsupported %s column type %T", e.Name, src)
+	}
+
+	return set(e, dest, value)
+}
+
+// unmarshal reads an enum from JSON. Empty strings are the unset zero value, anything else must be allowed.
+func unmarshal[T ~string](e Enum, dest *T, data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	return set(e, dest, value)
+}
+
+func set[T ~string](e Enum, dest *T, value string) error {
+	if value == "" {
+		*dest = ""
+		return nil
+	}
+
+	parsed, err := parse[T](e, value)
+	if err == nil {
+		*dest = parsed
+	}
+
+	return err
+}
+
+// value stores an enum, refusing values that don't belong to it. The zero value is stored as an empty string.
+func value[T ~string](e Enum, v T) (driver.Value, error) {
+	if v != "" && !e.contains(string(v)) {
+		return nil, &InvalidEnumError{e.Name, string(v), e.Allowed()}
+	}
+
+	return string(v), nil
+}
+
+var proficiencyTypes = Enum{"ProficiencyType", []EnumValue{
+	{string(SimpleWeapons), "Simple weapons", "Allows usage of small cold weapons, such as daggers, shortswords, handaxes, bows and crossbows."},
+	{string(ComplexWeapons), "Complex weapons", "Allows usage of bigger cold weapons, such as longswords, greataxes, lances, warbows and heavy crossbows."},
+	{string(CastMagic), "Cast magic", "Allows spellcasting, such as Wizard's Fireball or Hellfire."},
+	{string(ReadMagic), "Read magic", "Allows reading magically engraved items, such as spellbooks, runes or enchanted weapons."},
+	{string(Pickpocket), "Pickpocket", "Allows picking locks, disarming traps and stealing from unsuspecting pockets."},
+}}
+
+var roles = Enum{"Role", []EnumValue{
+	{string(Fighter), "Fighter", "Uses melee weapons and has greater overall endurance with high damage output. Excels at strength and defense, has high agility and hit points."},
+	{string(Spellcaster), "Spellcaster", "Versatile due its access to spells. Can sustain damage, provide support or be a jack-of-all-trades. Excels at intelligence and has high willpower and mana."},
+	{string(Dexterous), "Dexterous", "Cunning and deceiving, may fight at distance, work treacherously or avoid being noticed at all. Excels at agility and dodge, has high intelligence and balanced attributes."},
+}}
+
+var difficultyTypes = Enum{"DifficultyType", []EnumValue{
+	{string(Auto), "Automatic", "Always active (if passive) or automatically used upon activation, having no difficulty target, like Warrior's War Cry."},
+	{string(Fixed), "Fixed", "Has a fixed target number as difficulty, like 12 for Wizard's Fireball or Hellfire."},
+	{string(Variable), "Variable", "Depends on player roleplaying choices, like trying to levitate a small rock or a cow."},
+	{string(TargetPlus), "Target plus", "Set upon a target value, like the opponent's defense or dodge, with a modifier that can be positive, negative or zero."},
+}}
+
+var activations = Enum{"Activation", []EnumValue{
+	{string(Action), "Action", "Performed during your turn, such as Warrior's War Cry or Wizard's Hellfire."},
+	{string(Reaction), "Reaction", "Activates after some precondition happens, like in response to taking damage."},
+	{string(Passive), "Passive", "Always active, like Dwarf's Mountain Vigor."},
+}}
+
+var sources = Enum{"Source", []EnumValue{
+	{string(Base), "Base", "Can be learnt by anyone. Skill requirements must still be met."},
+	{string(FromRace), "Race", "Can only be accessed by members of a determined race."},
+	{string(FromClass), "Class", "Can only be accessed by members of a determined class."},
+	{string(FromAncestor), "Ancestor", "Must be learnt from your ancestral inheritance."},
+}}
+
+var skillTypes = Enum{"SkillType", []EnumValue{
+	{string(Ability), "Ability", "A simple skill, with nothing special about it."},
+	{string(Characteristic), "Characteristic", "Usually a passive or racial feat. Becomes your hero's way of being and might change attributes or physical appearance, like having four arms."},
+	{string(Technique), "Technique", "Usually requires proficiency and can be learnt by spending skill points or training with a mentor in-game."},
+	{string(Spell), "Spell", "Requires the cast magic proficiency and can be cast from memory or from a previously studied spellbook."},
+}}
+
+var levelRequirements = Enum{"LevelRequirement", []EnumValue{
+	{string(None), "None", "Can be learnt at any level."},
+	{string(Advanced), "Advanced", "Must be learnt at level 5 or above. These skills are powerful game changers."},
+	{string(Master), "Master", "Must be learnt at level 10 or above. Classes usually have one or two master skills at most, as they are ultimate skills."},
+	{string(Initial), "Initial", "Must be learnt at level 1, when you first create your hero sheet. This usually includes ancestor skills and racial feats."},
+}}
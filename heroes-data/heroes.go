@@ -1,5 +1,11 @@
 package heroes
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 // Race represents the player's hero being, like Human or Elf. They have base attributes for the character and learn racial skills.
 type Race struct {
 	ID                 uint64    `json:"id" gorm:"primary_key"`
@@ -9,6 +15,9 @@ type Race struct {
 	StartingSkills     []Skill   `json:"starting_skills" gorm:"many2many:race_starting_skills;"`
 	AvailableSkills    []Skill   `json:"available_skills" gorm:"many2many:race_available_skills;"`
 	RecommendedClasses []Class   `json:"recommendedClasses" gorm:"many2many:race_recommended_classes;"`
+	Slugged
+	Timestamps
+	Revision
 }
 
 // Class represents how a hero is specialized, like Warrior or Wizard. They give bonus attributes depending on their skillset.
@@ -21,6 +30,9 @@ type Class struct {
 	Proficiencies   []Proficiency `json:"proficiencies" gorm:"many2many:class_proficiencies;"`
 	StartingSkills  []Skill       `json:"starting_skills" gorm:"many2many:class_starting_skills;"`
 	AvailableSkills []Skill       `json:"available_skills" gorm:"many2many:class_available_skills;"`
+	Slugged
+	Timestamps
+	Revision
 }
 
 // Skill is a hero ability.
@@ -39,6 +51,9 @@ type Skill struct {
 	LevelRequirement  LevelRequirement `json:"level_requirement" gorm:"embedded"`
 	SkillRequirements []Skill          `json:"skill_requirement" gorm:"many2many:skill_requirements;"`
 	Observations      string           `json:"observations"`
+	Slugged
+	Timestamps
+	Revision
 }
 
 // Attribute is a hero measurement of power. Heroes have strength (physical power), agility (velocity and dexterity),
@@ -55,6 +70,31 @@ type Attribute struct {
 type Proficiency struct {
 	ID   uint64          `json:"id" gorm:"primary_key"`
 	Name ProficiencyType `json:"proficiency_type" gorm:"embedded"`
+	Timestamps
+	Revision
+}
+
+// Timestamps tracks when an entity was created and last changed. Entities with DeletedAt set are soft deleted:
+// they are hidden from every query but can still be restored.
+type Timestamps struct {
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// Revision is an optimistic concurrency token. Every update increments Version, so stale writes can be detected.
+type Revision struct {
+	Version uint64 `json:"version" gorm:"not null;default:1"`
+}
+
+// GetVersion returns the entity's current version.
+func (r *Revision) GetVersion() uint64 {
+	return r.Version
+}
+
+// SetVersion overrides the entity's version.
+func (r *Revision) SetVersion(version uint64) {
+	r.Version = version
 }
 
 // ProficiencyType specifies the kind of natural abilities this class can make use of.
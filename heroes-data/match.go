@@ -0,0 +1,94 @@
+package heroes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Match tells how a list of wanted values is compared against the values an entity has, such as a class proficiencies.
+type Match string
+
+const (
+	// MatchAny entities have at least one of the wanted values. This is the default.
+	MatchAny Match = "any"
+	// MatchAll entities have every wanted value.
+	MatchAll Match = "all"
+	// MatchNone entities have none of the wanted values.
+	MatchNone Match = "none"
+)
+
+// ParseMatch reads a match mode ignoring case. Empty values are MatchAny.
+func ParseMatch(value string) (Match, error) {
+	switch match := Match(strings.ToLower(strings.TrimSpace(value))); match {
+	case "":
+		return MatchAny, nil
+	case MatchAny, MatchAll, MatchNone:
+		return match, nil
+	default:
+		return "", fmt.Errorf("match should be one of any, all, none. Invalid value received: %q", value)
+	}
+}
+
+// Matches compares, ignoring case, the values an entity has against the wanted ones.
+// This is the in-memory counterpart of the SQL filters used by the API, so both must agree.
+func (m Match) Matches(has []string, wanted []string) bool {
+	owned := map[string]bool{}
+	for _, value := range has {
+		owned[strings.ToLower(value)] = true
+	}
+
+	found := 0
+	for _, value := range Normalize(wanted) {
+		if owned[value] {
+			found++
+		}
+	}
+
+	switch m {
+	case MatchAll:
+		return found == len(Normalize(wanted))
+	case MatchNone:
+		return found == 0
+	default:
+		return found > 0
+	}
+}
+
+// Normalize lowercases values and drops duplicates, keeping their order.
+func Normalize(values []string) []string {
+	seen := map[string]bool{}
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); !seen[value] {
+			seen[value] = true
+			normalized = append(normalized, value)
+		}
+	}
+
+	return normalized
+}
+
+// HasProficiencies tells whether the class proficiencies match the wanted ones.
+func (c *Class) HasProficiencies(m Match, wanted ...ProficiencyType) bool {
+	has := make([]string, len(c.Proficiencies))
+	for i, proficiency := range c.Proficiencies {
+		has[i] = string(proficiency.Name)
+	}
+
+	names := make([]string, len(wanted))
+	for i, proficiency := range wanted {
+		names[i] = string(proficiency)
+	}
+
+	return m.Matches(has, names)
+}
+
+// RecommendsClasses tells whether the race recommended class names match the wanted ones.
+func (r *Race) RecommendsClasses(m Match, wanted ...string) bool {
+	has := make([]string, len(r.RecommendedClasses))
+	for i, class := range r.RecommendedClasses {
+		has[i] = class.Name
+	}
+
+	return m.Matches(has, wanted)
+}
@@ -0,0 +1,60 @@
+package heroes
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugged gives an entity a stable, URL friendly alternate key to its ID, such as "high-elf".
+// It is derived from the name when the entity is created, but doesn't follow later renames, so links keep working.
+type Slugged struct {
+	Slug string `json:"slug" gorm:"uniqueIndex:,where:slug <> ''"`
+}
+
+// GetSlug returns the entity's slug.
+func (s *Slugged) GetSlug() string {
+	return s.Slug
+}
+
+// SetSlug overrides the entity's slug.
+func (s *Slugged) SetSlug(slug string) {
+	s.Slug = slug
+}
+
+// GetName returns the race's name, which its slug is derived from.
+func (r *Race) GetName() string {
+	return r.Name
+}
+
+// GetName returns the class' name, which its slug is derived from.
+func (c *Class) GetName() string {
+	return c.Name
+}
+
+// GetName returns the skill's name, which its slug is derived from.
+func (s *Skill) GetName() string {
+	return s.Name
+}
+
+// Fold lowercases the text and strips its accents, such as "Mágia Élfica" into "magia elfica".
+func Fold(text string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+
+	return strings.ToLower(folded)
+}
+
+// Slugify turns a name into a slug, such as "Mágia Élfica" into "magia-elfica".
+func Slugify(name string) string {
+	words := strings.FieldsFunc(Fold(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
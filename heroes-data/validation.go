@@ -0,0 +1,181 @@
+package heroes
+
+import "database/sql/driver"
+
+// Every enum type can be parsed from user input, validated, and is checked when read from or written to JSON and the
+// database. Their zero value means unset: it is accepted by JSON and the database, but is never Valid.
+
+// Valid tells whether the proficiency type is one of the allowed values.
+func (p ProficiencyType) Valid() bool {
+	return proficiencyTypes.contains(string(p))
+}
+
+// ParseProficiencyType reads a proficiency type ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseProficiencyType(value string) (ProficiencyType, error) {
+	return parse[ProficiencyType](proficiencyTypes, value)
+}
+
+// Scan implements sql.Scanner.
+func (p *ProficiencyType) Scan(src interface{}) error {
+	return scan(proficiencyTypes, p, src)
+}
+
+// Value implements driver.Valuer.
+func (p ProficiencyType) Value() (driver.Value, error) {
+	return value(proficiencyTypes, p)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ProficiencyType) UnmarshalJSON(data []byte) error {
+	return unmarshal(proficiencyTypes, p, data)
+}
+
+// Valid tells whether the role is one of the allowed values.
+func (r Role) Valid() bool {
+	return roles.contains(string(r))
+}
+
+// ParseRole reads a role ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseRole(value string) (Role, error) {
+	return parse[Role](roles, value)
+}
+
+// Scan implements sql.Scanner.
+func (r *Role) Scan(src interface{}) error {
+	return scan(roles, r, src)
+}
+
+// Value implements driver.Valuer.
+func (r Role) Value() (driver.Value, error) {
+	return value(roles, r)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	return unmarshal(roles, r, data)
+}
+
+// Valid tells whether the difficulty type is one of the allowed values.
+func (d DifficultyType) Valid() bool {
+	return difficultyTypes.contains(string(d))
+}
+
+// ParseDifficultyType reads a difficulty type ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseDifficultyType(value string) (DifficultyType, error) {
+	return parse[DifficultyType](difficultyTypes, value)
+}
+
+// Scan implements sql.Scanner.
+func (d *DifficultyType) Scan(src interface{}) error {
+	return scan(difficultyTypes, d, src)
+}
+
+// Value implements driver.Valuer.
+func (d DifficultyType) Value() (driver.Value, error) {
+	return value(difficultyTypes, d)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *DifficultyType) UnmarshalJSON(data []byte) error {
+	return unmarshal(difficultyTypes, d, data)
+}
+
+// Valid tells whether the activation is one of the allowed values.
+func (a Activation) Valid() bool {
+	return activations.contains(string(a))
+}
+
+// ParseActivation reads a activation ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseActivation(value string) (Activation, error) {
+	return parse[Activation](activations, value)
+}
+
+// Scan implements sql.Scanner.
+func (a *Activation) Scan(src interface{}) error {
+	return scan(activations, a, src)
+}
+
+// Value implements driver.Valuer.
+func (a Activation) Value() (driver.Value, error) {
+	return value(activations, a)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Activation) UnmarshalJSON(data []byte) error {
+	return unmarshal(activations, a, data)
+}
+
+// Valid tells whether the source is one of the allowed values.
+func (s Source) Valid() bool {
+	return sources.contains(string(s))
+}
+
+// ParseSource reads a source ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseSource(value string) (Source, error) {
+	return parse[Source](sources, value)
+}
+
+// Scan implements sql.Scanner.
+func (s *Source) Scan(src interface{}) error {
+	return scan(sources, s, src)
+}
+
+// Value implements driver.Valuer.
+func (s Source) Value() (driver.Value, error) {
+	return value(sources, s)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Source) UnmarshalJSON(data []byte) error {
+	return unmarshal(sources, s, data)
+}
+
+// Valid tells whether the skill type is one of the allowed values.
+func (t SkillType) Valid() bool {
+	return skillTypes.contains(string(t))
+}
+
+// ParseSkillType reads a skill type ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseSkillType(value string) (SkillType, error) {
+	return parse[SkillType](skillTypes, value)
+}
+
+// Scan implements sql.Scanner.
+func (t *SkillType) Scan(src interface{}) error {
+	return scan(skillTypes, t, src)
+}
+
+// Value implements driver.Valuer.
+func (t SkillType) Value() (driver.Value, error) {
+	return value(skillTypes, t)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *SkillType) UnmarshalJSON(data []byte) error {
+	return unmarshal(skillTypes, t, data)
+}
+
+// Valid tells whether the level requirement is one of the allowed values.
+func (l LevelRequirement) Valid() bool {
+	return levelRequirements.contains(string(l))
+}
+
+// ParseLevelRequirement reads a level requirement ignoring case, failing with *InvalidEnumError for unknown values.
+func ParseLevelRequirement(value string) (LevelRequirement, error) {
+	return parse[LevelRequirement](levelRequirements, value)
+}
+
+// Scan implements sql.Scanner.
+func (l *LevelRequirement) Scan(src interface{}) error {
+	return scan(levelRequirements, l, src)
+}
+
+// Value implements driver.Valuer.
+func (l LevelRequirement) Value() (driver.Value, error) {
+	return value(levelRequirements, l)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *LevelRequirement) UnmarshalJSON(data []byte) error {
+	return unmarshal(levelRequirements, l, data)
+}
@@ -0,0 +1,413 @@
+// Package compendium imports and exports every race, class, skill and proficiency at once, as JSON, YAML or CSV.
+// Records reference their associations by slug instead of nesting them, so they fit a spreadsheet row.
+package compendium
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"gorm.io/gorm"
+)
+
+// errLoad is returned when the current entities can't be read, already logged by the repository.
+var errLoad = errors.New("unable to load the compendium")
+
+// Compendium holds records of every entity type, in the order they are imported.
+type Compendium struct {
+	Proficiencies []Proficiency `json:"proficiencies,omitempty"`
+	Skills        []Skill       `json:"skills,omitempty"`
+	Classes       []Class       `json:"classes,omitempty"`
+	Races         []Race        `json:"races,omitempty"`
+}
+
+// Proficiency is a heroes.Proficiency as imported and exported. Proficiencies are only ever created, never updated.
+type Proficiency struct {
+	ID   uint64                 `json:"id,omitempty"`
+	Name heroes.ProficiencyType `json:"proficiency_type"`
+}
+
+// Skill is a heroes.Skill as imported and exported, referencing its requirements by slug.
+type Skill struct {
+	ID                uint64                  `json:"id,omitempty"`
+	Slug              string                  `json:"slug,omitempty"`
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	Bonus             string                  `json:"bonus"`
+	Mana              string                  `json:"mana"`
+	DifficultyType    heroes.DifficultyType   `json:"difficulty_type"`
+	Difficulty        string                  `json:"difficulty"`
+	Activation        heroes.Activation       `json:"activation"`
+	Source            heroes.Source           `json:"source"`
+	Type              heroes.SkillType        `json:"type"`
+	LevelRequirement  heroes.LevelRequirement `json:"level_requirement"`
+	SkillRequirements []string                `json:"skill_requirements"`
+	Observations      string                  `json:"observations"`
+	Version           uint64                  `json:"version,omitempty"`
+}
+
+// Class is a heroes.Class as imported and exported, referencing proficiencies by type and skills by slug.
+type Class struct {
+	ID              uint64                   `json:"id,omitempty"`
+	Slug            string                   `json:"slug,omitempty"`
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description"`
+	BonusAttributes heroes.Attribute         `json:"bonus_attributes"`
+	Role            heroes.Role              `json:"role"`
+	Proficiencies   []heroes.ProficiencyType `json:"proficiencies"`
+	StartingSkills  []string                 `json:"starting_skills"`
+	AvailableSkills []string                 `json:"available_skills"`
+	Version         uint64                   `json:"version,omitempty"`
+}
+
+// Race is a heroes.Race as imported and exported, referencing skills and classes by slug.
+type Race struct {
+	ID                 uint64           `json:"id,omitempty"`
+	Slug               string           `json:"slug,omitempty"`
+	Name               string           `json:"name"`
+	Description        string           `json:"description"`
+	BaseAttributes     heroes.Attribute `json:"base_attributes"`
+	StartingSkills     []string         `json:"starting_skills"`
+	AvailableSkills    []string         `json:"available_skills"`
+	RecommendedClasses []string         `json:"recommended_classes"`
+	Version            uint64           `json:"version,omitempty"`
+}
+
+// record is implemented by every record type R, telling how it is matched against stored entities.
+type record[R any] interface {
+	// key identifies the record when it has no ID: its slug, or its type for proficiencies.
+	key() string
+	identity() (id uint64, version uint64)
+	// normalized derives the slug from the name when missing, and sorts associations so records can be compared.
+	normalized() R
+	// at targets the stored entity with the provided ID and version.
+	at(id uint64, version uint64) R
+}
+
+// kind describes how entities of type E are converted from and to records of type R.
+type kind[E any, R record[R]] struct {
+	entity       string
+	recordOf     func(entity *E) R
+	entityOf     func(record R) E
+	associations []association[R]
+}
+
+// association is a many to many field of the entity, referenced by key in records.
+type association[R any] struct {
+	// field is the Go field of the entity, such as "StartingSkills".
+	field string
+	// target is the entity type of the keys, such as "skills".
+	target string
+	keys   func(record R) []string
+}
+
+var proficiencies = kind[heroes.Proficiency, Proficiency]{
+	entity: "proficiencies",
+	recordOf: func(p *heroes.Proficiency) Proficiency {
+		return Proficiency{ID: p.ID, Name: p.Name}
+	},
+	entityOf: func(p Proficiency) heroes.Proficiency {
+		return heroes.Proficiency{ID: p.ID, Name: p.Name}
+	},
+}
+
+var skills = kind[heroes.Skill, Skill]{
+	entity: "skills",
+	recordOf: func(s *heroes.Skill) Skill {
+		return Skill{
+			ID:                s.ID,
+			Slug:              s.Slug,
+			Name:              s.Name,
+			Description:       s.Description,
+			Bonus:             s.Bonus,
+			Mana:              s.Mana,
+			DifficultyType:    s.DifficultyType,
+			Difficulty:        s.Difficulty,
+			Activation:        s.Activation,
+			Source:            s.Source,
+			Type:              s.Type,
+			LevelRequirement:  s.LevelRequirement,
+			SkillRequirements: skillSlugs(s.SkillRequirements),
+			Observations:      s.Observations,
+			Version:           s.Version,
+		}
+	},
+	entityOf: func(s Skill) heroes.Skill {
+		return heroes.Skill{
+			ID:               s.ID,
+			Name:             s.Name,
+			Description:      s.Description,
+			Bonus:            s.Bonus,
+			Mana:             s.Mana,
+			DifficultyType:   s.DifficultyType,
+			Difficulty:       s.Difficulty,
+			Activation:       s.Activation,
+			Source:           s.Source,
+			Type:             s.Type,
+			LevelRequirement: s.LevelRequirement,
+			Observations:     s.Observations,
+			Slugged:          heroes.Slugged{Slug: s.Slug},
+		}
+	},
+	associations: []association[Skill]{
+		{"SkillRequirements", "skills", func(s Skill) []string { return s.SkillRequirements }},
+	},
+}
+
+var classes = kind[heroes.Class, Class]{
+	entity: "classes",
+	recordOf: func(c *heroes.Class) Class {
+		proficiencies := make([]heroes.ProficiencyType, len(c.Proficiencies))
+		for i, proficiency := range c.Proficiencies {
+			proficiencies[i] = proficiency.Name
+		}
+
+		return Class{
+			ID:              c.ID,
+			Slug:            c.Slug,
+			Name:            c.Name,
+			Description:     c.Description,
+			BonusAttributes: c.BonusAttributes,
+			Role:            c.Role,
+			Proficiencies:   sorted(proficiencies),
+			StartingSkills:  skillSlugs(c.StartingSkills),
+			AvailableSkills: skillSlugs(c.AvailableSkills),
+			Version:         c.Version,
+		}
+	},
+	entityOf: func(c Class) heroes.Class {
+		return heroes.Class{
+			ID:              c.ID,
+			Name:            c.Name,
+			Description:     c.Description,
+			BonusAttributes: c.BonusAttributes,
+			Role:            c.Role,
+			Slugged:         heroes.Slugged{Slug: c.Slug},
+		}
+	},
+	associations: []association[Class]{
+		{"Proficiencies", "proficiencies", func(c Class) []string {
+			keys := make([]string, len(c.Proficiencies))
+			for i, proficiency := range c.Proficiencies {
+				keys[i] = string(proficiency)
+			}
+			return keys
+		}},
+		{"StartingSkills", "skills", func(c Class) []string { return c.StartingSkills }},
+		{"AvailableSkills", "skills", func(c Class) []string { return c.AvailableSkills }},
+	},
+}
+
+var races = kind[heroes.Race, Race]{
+	entity: "races",
+	recordOf: func(r *heroes.Race) Race {
+		classes := make([]string, len(r.RecommendedClasses))
+		for i, class := range r.RecommendedClasses {
+			classes[i] = class.Slug
+		}
+
+		return Race{
+			ID:                 r.ID,
+			Slug:               r.Slug,
+			Name:               r.Name,
+			Description:        r.Description,
+			BaseAttributes:     r.BaseAttributes,
+			StartingSkills:     skillSlugs(r.StartingSkills),
+			AvailableSkills:    skillSlugs(r.AvailableSkills),
+			RecommendedClasses: sorted(classes),
+			Version:            r.Version,
+		}
+	},
+	entityOf: func(r Race) heroes.Race {
+		return heroes.Race{
+			ID:             r.ID,
+			Name:           r.Name,
+			Description:    r.Description,
+			BaseAttributes: r.BaseAttributes,
+			Slugged:        heroes.Slugged{Slug: r.Slug},
+		}
+	},
+	associations: []association[Race]{
+		{"StartingSkills", "skills", func(r Race) []string { return r.StartingSkills }},
+		{"AvailableSkills", "skills", func(r Race) []string { return r.AvailableSkills }},
+		{"RecommendedClasses", "classes", func(r Race) []string { return r.RecommendedClasses }},
+	},
+}
+
+// Export reads every entity that is not deleted ordered by ID, only the ones of the provided entity type when not empty.
+func Export(repository database.Repository, entity string) (Compendium, error) {
+	var c Compendium
+	for _, step := range []struct {
+		entity string
+		export func() error
+	}{
+		{proficiencies.entity, func() (err error) { c.Proficiencies, err = export(repository, proficiencies); return }},
+		{skills.entity, func() (err error) { c.Skills, err = export(repository, skills); return }},
+		{classes.entity, func() (err error) { c.Classes, err = export(repository, classes); return }},
+		{races.entity, func() (err error) { c.Races, err = export(repository, races); return }},
+	} {
+		if entity != "" && entity != step.entity {
+			continue
+		}
+
+		if err := step.export(); err != nil {
+			return c, err
+		}
+	}
+
+	return c, nil
+}
+
+func export[E any, R record[R]](repository database.Repository, k kind[E, R]) ([]R, error) {
+	entities, ok := load(repository, k)
+	if !ok {
+		return nil, errLoad
+	}
+
+	// Entities may be shared with the cache, so only the records built from them are sorted.
+	records := make([]R, len(entities))
+	for i := range entities {
+		records[i] = k.recordOf(&entities[i])
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		a, _ := records[i].identity()
+		b, _ := records[j].identity()
+		return a < b
+	})
+	return records, nil
+}
+
+// load finds every entity of the kind along with its associations.
+func load[E any, R record[R]](repository database.Repository, k kind[E, R]) ([]E, bool) {
+	fields := make([]string, len(k.associations))
+	for i, a := range k.associations {
+		fields[i] = a.field
+	}
+
+	var entities []E
+	return entities, repository.Expand(fields...).FindAll(&entities)
+}
+
+func (p Proficiency) key() string {
+	return string(p.Name)
+}
+
+func (p Proficiency) identity() (uint64, uint64) {
+	return p.ID, 0
+}
+
+func (p Proficiency) normalized() Proficiency {
+	return p
+}
+
+func (p Proficiency) at(id uint64, _ uint64) Proficiency {
+	p.ID = id
+	return p
+}
+
+func (s Skill) key() string {
+	return s.Slug
+}
+
+func (s Skill) identity() (uint64, uint64) {
+	return s.ID, s.Version
+}
+
+func (s Skill) normalized() Skill {
+	s.Slug = slugOf(s.Slug, s.Name)
+	s.SkillRequirements = slugs(s.SkillRequirements)
+	return s
+}
+
+func (s Skill) at(id uint64, version uint64) Skill {
+	s.ID, s.Version = id, version
+	return s
+}
+
+func (c Class) key() string {
+	return c.Slug
+}
+
+func (c Class) identity() (uint64, uint64) {
+	return c.ID, c.Version
+}
+
+func (c Class) normalized() Class {
+	c.Slug = slugOf(c.Slug, c.Name)
+	c.Proficiencies = sorted(c.Proficiencies)
+	c.StartingSkills = slugs(c.StartingSkills)
+	c.AvailableSkills = slugs(c.AvailableSkills)
+	return c
+}
+
+func (c Class) at(id uint64, version uint64) Class {
+	c.ID, c.Version = id, version
+	return c
+}
+
+func (r Race) key() string {
+	return r.Slug
+}
+
+func (r Race) identity() (uint64, uint64) {
+	return r.ID, r.Version
+}
+
+func (r Race) normalized() Race {
+	r.Slug = slugOf(r.Slug, r.Name)
+	r.StartingSkills = slugs(r.StartingSkills)
+	r.AvailableSkills = slugs(r.AvailableSkills)
+	r.RecommendedClasses = slugs(r.RecommendedClasses)
+	return r
+}
+
+func (r Race) at(id uint64, version uint64) Race {
+	r.ID, r.Version = id, version
+	return r
+}
+
+// slugOf normalizes the slug, deriving it from the name when missing like the repository does.
+func slugOf(slug string, name string) string {
+	if slug = heroes.Slugify(slug); slug == "" {
+		slug = heroes.Slugify(name)
+	}
+
+	return slug
+}
+
+// slugs normalizes references so names can be used in place of slugs, as in "Mountain Vigor".
+func slugs(references []string) []string {
+	result := make([]string, 0, len(references))
+	for _, reference := range references {
+		if slug := heroes.Slugify(reference); slug != "" {
+			result = append(result, slug)
+		}
+	}
+
+	return sorted(result)
+}
+
+func skillSlugs(skills []heroes.Skill) []string {
+	result := make([]string, len(skills))
+	for i, skill := range skills {
+		result[i] = skill.Slug
+	}
+
+	return sorted(result)
+}
+
+func sorted[T ~string](values []T) []T {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func idOf(entity interface{}) uint64 {
+	return reflect.Indirect(reflect.ValueOf(entity)).FieldByName("ID").Uint()
+}
+
+func deleted(entity interface{}) bool {
+	return reflect.Indirect(reflect.ValueOf(entity)).FieldByName("DeletedAt").Interface().(gorm.DeletedAt).Valid
+}
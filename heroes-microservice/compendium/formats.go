@@ -0,0 +1,363 @@
+package compendium
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format is how a Compendium is written and read.
+type Format string
+
+const (
+	// JSON holds every entity type in a single document. It is the default format.
+	JSON Format = "json"
+	// YAML holds the same document as JSON, friendlier to edit by hand.
+	YAML Format = "yaml"
+	// CSV holds records of a single entity type, one per row. References are separated by commas within their cell,
+	// and nested fields have their own columns, such as "base_attributes.strength".
+	CSV Format = "csv"
+)
+
+// Entities are the entity types of a Compendium, in the order they are imported.
+var Entities = []string{"proficiencies", "skills", "classes", "races"}
+
+// optionalColumns may be left out of CSV files, such as when entries are added from scratch.
+var optionalColumns = map[string]bool{"id": true, "slug": true, "version": true}
+
+// ParseFormat reads a format ignoring case. Empty values mean JSON.
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case "":
+		return JSON, nil
+	case JSON, YAML, CSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected json, yaml or csv", value)
+	}
+}
+
+// ContentType is the media type of documents in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case YAML:
+		return "application/yaml"
+	case CSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// CheckEntity validates the entity type to write or read in the format. Only CSV requires one.
+func CheckEntity(format Format, entity string) error {
+	if entity == "" && format == CSV {
+		return fmt.Errorf("CSV holds a single entity type, choose one of %s", strings.Join(Entities, ", "))
+	}
+
+	for _, e := range Entities {
+		if e == entity || entity == "" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown entity type %q, expected one of %s", entity, strings.Join(Entities, ", "))
+}
+
+// Encode writes the compendium in the format, keeping only records of the provided entity type when not empty.
+func Encode(w io.Writer, c Compendium, format Format, entity string) error {
+	if err := CheckEntity(format, entity); err != nil {
+		return err
+	}
+
+	c = only(c, entity)
+	switch format {
+	case CSV:
+		return encodeCSV(w, recordsOf(&c, entity))
+	case YAML:
+		// Going through JSON keeps the same field names in both formats.
+		content, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+
+		var document interface{}
+		if err := json.Unmarshal(content, &document); err != nil {
+			return err
+		}
+
+		if content, err = yaml.Marshal(document); err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	default:
+		content, err := json.MarshalIndent(c, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(content))
+		return err
+	}
+}
+
+// Decode reads a compendium in the format. CSV rows are records of the provided entity type, while other formats drop
+// records of other types when one is provided. Unknown fields and columns are rejected, so typos don't go unnoticed.
+func Decode(r io.Reader, format Format, entity string) (Compendium, error) {
+	var c Compendium
+	if err := CheckEntity(format, entity); err != nil {
+		return c, err
+	}
+
+	switch format {
+	case CSV:
+		return c, decodeCSV(r, recordsOf(&c, entity))
+	case YAML:
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return c, err
+		}
+
+		var document interface{}
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return c, err
+		}
+
+		if content, err = json.Marshal(jsonCompatible(document)); err != nil {
+			return c, err
+		}
+		r = bytes.NewReader(content)
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&c); err != nil {
+		return c, err
+	}
+
+	return only(c, entity), nil
+}
+
+// only drops every record not of the provided entity type, unless it is empty.
+func only(c Compendium, entity string) Compendium {
+	if entity == "" {
+		return c
+	}
+
+	var result Compendium
+	recordsOf(&result, entity).Set(recordsOf(&c, entity))
+	return result
+}
+
+// recordsOf is the list of records of the provided entity type, named after its JSON field.
+func recordsOf(c *Compendium, entity string) reflect.Value {
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if jsonName(value.Type().Field(i)) == entity {
+			return value.Field(i)
+		}
+	}
+
+	panic("compendium: unknown entity type " + entity)
+}
+
+// column is a CSV column holding a record field, found by its index path.
+type column struct {
+	name  string
+	index []int
+}
+
+// columnsOf lists every field of the record type as a column, flattening nested structs with dotted names.
+func columnsOf(t reflect.Type, prefix string, index []int) []column {
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := prefix + jsonName(field)
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Type.Kind() == reflect.Struct {
+			columns = append(columns, columnsOf(field.Type, name+".", fieldIndex)...)
+		} else {
+			columns = append(columns, column{name, fieldIndex})
+		}
+	}
+
+	return columns
+}
+
+func encodeCSV(w io.Writer, records reflect.Value) error {
+	columns := columnsOf(records.Type().Elem(), "", nil)
+	out := csv.NewWriter(w)
+
+	row := make([]string, len(columns))
+	for i, c := range columns {
+		row[i] = c.name
+	}
+	if err := out.Write(row); err != nil {
+		return err
+	}
+
+	for i := 0; i < records.Len(); i++ {
+		for j, c := range columns {
+			row[j] = formatCell(records.Index(i).FieldByIndex(c.index))
+		}
+
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func decodeCSV(r io.Reader, records reflect.Value) error {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		return errors.New("missing the header row")
+	}
+
+	columns := columnsOf(records.Type().Elem(), "", nil)
+	byName := map[string]column{}
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+
+	header := make([]column, len(rows[0]))
+	present := map[string]bool{}
+	for i, name := range rows[0] {
+		// Spreadsheets often save a byte order mark before the first column.
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		c, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown column %q", name)
+		}
+
+		header[i] = c
+		present[name] = true
+	}
+
+	for _, c := range columns {
+		if !present[c.name] && !optionalColumns[c.name] {
+			return fmt.Errorf("missing column %q", c.name)
+		}
+	}
+
+	for n, row := range rows[1:] {
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		record := reflect.New(records.Type().Elem()).Elem()
+		for i, cell := range row {
+			if err := parseCell(record.FieldByIndex(header[i].index), cell); err != nil {
+				// Rows are numbered as spreadsheets do, counting the header.
+				return fmt.Errorf("row %d, column %s: %w", n+2, header[i].name, err)
+			}
+		}
+		records.Set(reflect.Append(records, record))
+	}
+
+	return nil
+}
+
+func formatCell(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = formatCell(value.Index(i))
+		}
+		return strings.Join(items, ", ")
+	case reflect.Uint64:
+		// Zero IDs and versions mean none.
+		if value.Uint() == 0 {
+			return ""
+		}
+		return strconv.FormatUint(value.Uint(), 10)
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func parseCell(value reflect.Value, cell string) error {
+	cell = strings.TrimSpace(cell)
+	switch value.Kind() {
+	case reflect.Slice:
+		for _, item := range strings.Split(cell, ",") {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+
+			element := reflect.New(value.Type().Elem()).Elem()
+			if err := parseCell(element, item); err != nil {
+				return err
+			}
+			value.Set(reflect.Append(value, element))
+		}
+		return nil
+	case reflect.Uint64:
+		if cell == "" {
+			return nil
+		}
+
+		number, err := strconv.ParseUint(cell, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q should be a positive number", cell)
+		}
+		value.SetUint(number)
+		return nil
+	case reflect.Int:
+		if cell == "" {
+			return nil
+		}
+
+		number, err := strconv.Atoi(cell)
+		if err != nil {
+			return fmt.Errorf("%q should be a number", cell)
+		}
+		value.SetInt(int64(number))
+		return nil
+	default:
+		// Going through JSON validates enums just like the other formats.
+		content, err := json.Marshal(cell)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(content, value.Addr().Interface())
+	}
+}
+
+// jsonCompatible converts the maps decoded from YAML, which may have keys of any type, into maps JSON can hold.
+func jsonCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
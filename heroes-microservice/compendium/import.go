@@ -0,0 +1,216 @@
+package compendium
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// ErrConflicts is returned along with the Report when some records can't be imported, in which case nothing is.
+var ErrConflicts = errors.New("the compendium has conflicts, nothing was imported")
+
+// errDryRun rolls back dry runs once every write was tried.
+var errDryRun = errors.New("dry run")
+
+// Report tells what an import did, or would do when DryRun.
+type Report struct {
+	DryRun    bool    `json:"dry_run"`
+	Created   []Entry `json:"created"`
+	Updated   []Entry `json:"updated"`
+	Unchanged []Entry `json:"unchanged"`
+	Conflicts []Entry `json:"conflicts"`
+}
+
+// Entry is a single record of the import, identified by entity type and key: its slug, or its type for proficiencies.
+// Conflicts explain what is wrong in Message.
+type Entry struct {
+	Entity  string `json:"entity"`
+	ID      uint64 `json:"id,omitempty"`
+	Key     string `json:"key"`
+	Message string `json:"message,omitempty"`
+}
+
+// importer carries what every kind of record needs while importing.
+type importer struct {
+	repository database.Repository
+	report     *Report
+	// keys resolves references to IDs by entity type and key, for stored and imported entities alike.
+	keys map[string]map[string]uint64
+	// links replace associations once every entity they may reference was written.
+	links []func() error
+}
+
+// Import creates records matching no entity and updates the ones that changed, all in a single transaction.
+// Records are matched by ID when they have one, otherwise by slug (derived from the name when missing) or proficiency
+// type. Versions, when present, must still be the current ones. Associations are replaced by the ones referenced.
+// With dryRun everything is rolled back, so the Report only tells what would happen.
+func Import(repository database.Repository, c Compendium, dryRun bool) (Report, error) {
+	report := Report{DryRun: dryRun, Created: []Entry{}, Updated: []Entry{}, Unchanged: []Entry{}, Conflicts: []Entry{}}
+	err := repository.Transaction(func(tx database.Repository) error {
+		im := &importer{repository: tx, report: &report, keys: map[string]map[string]uint64{}}
+		for _, step := range []func() error{
+			func() error { return upsert(im, proficiencies, c.Proficiencies) },
+			func() error { return upsert(im, skills, c.Skills) },
+			func() error { return upsert(im, classes, c.Classes) },
+			func() error { return upsert(im, races, c.Races) },
+		} {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+
+		for _, link := range im.links {
+			if err := link(); err != nil {
+				return err
+			}
+		}
+
+		if len(report.Conflicts) > 0 {
+			return ErrConflicts
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		// IDs of entities that were never committed mean nothing.
+		for i := range report.Created {
+			report.Created[i].ID = 0
+		}
+	}
+
+	if errors.Is(err, errDryRun) {
+		return report, nil
+	}
+
+	return report, err
+}
+
+// upsert writes the columns of every record of the kind, leaving associations to links.
+func upsert[E any, R record[R]](im *importer, k kind[E, R], records []R) error {
+	entities, ok := load(im.repository.Unscoped(), k)
+	if !ok {
+		return errLoad
+	}
+
+	stored := map[string]R{}
+	byID := map[uint64]R{}
+	isDeleted := map[uint64]bool{}
+	keys := map[string]uint64{}
+	for i := range entities {
+		r, id := k.recordOf(&entities[i]), idOf(&entities[i])
+		stored[r.key()], byID[id] = r, r
+		if deleted(&entities[i]) {
+			isDeleted[id] = true
+		} else {
+			keys[r.key()] = id
+		}
+	}
+	im.keys[k.entity] = keys
+
+	seen := map[string]bool{}
+	for _, r := range records {
+		r = r.normalized()
+		id, version := r.identity()
+		entry := Entry{Entity: k.entity, ID: id, Key: r.key()}
+
+		current, found := stored[r.key()]
+		holder, _ := current.identity()
+		if id > 0 {
+			current, found = byID[id]
+		}
+
+		currentID, currentVersion := current.identity()
+		switch {
+		case r.key() == "":
+			im.conflict(entry, "name is required")
+			continue
+		case seen[r.key()]:
+			im.conflict(entry, "appears more than once")
+			continue
+		case id > 0 && !found:
+			im.conflict(entry, fmt.Sprintf("there is no %s with ID %d", k.entity, id))
+			continue
+		case id > 0 && holder > 0 && holder != id:
+			im.conflict(entry, fmt.Sprintf("%s is taken by another entry of %s", r.key(), k.entity))
+			continue
+		case found && isDeleted[currentID]:
+			im.conflict(entry, "was deleted, restore it before importing")
+			continue
+		}
+		seen[r.key()] = true
+
+		if found && fmt.Sprintf("%+v", r.at(currentID, currentVersion)) == fmt.Sprintf("%+v", current) {
+			// Printing tells apart any changed field, while nil and empty lists look alike.
+			entry.ID = currentID
+			im.report.Unchanged = append(im.report.Unchanged, entry)
+			continue
+		}
+
+		if found && version > 0 && version != currentVersion {
+			im.conflict(entry, fmt.Sprintf("was changed since exported: version %d is now %d", version, currentVersion))
+			continue
+		}
+
+		entity := k.entityOf(r.at(currentID, currentVersion))
+		if found {
+			if err := im.repository.Update(&entity, currentID, currentVersion); err != nil {
+				return err
+			}
+			delete(keys, current.key())
+			im.report.Updated = append(im.report.Updated, Entry{Entity: k.entity, ID: currentID, Key: r.key()})
+		} else {
+			if err := im.repository.Create(&entity); err != nil {
+				return err
+			}
+			im.report.Created = append(im.report.Created, Entry{Entity: k.entity, ID: idOf(&entity), Key: r.key()})
+		}
+		keys[r.key()] = idOf(&entity)
+
+		for _, a := range k.associations {
+			if !found || fmt.Sprint(a.keys(r)) != fmt.Sprint(a.keys(current)) {
+				im.link(entry, &entity, a.field, a.target, a.keys(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+// link queues the replacement of the owner's association by the entities of the target type with the provided keys.
+// Keys matching no entity are conflicts.
+func (im *importer) link(entry Entry, owner interface{}, field string, target string, keys []string) {
+	im.links = append(im.links, func() error {
+		// Association values only need their IDs, as the entities themselves are written apart.
+		values := reflect.New(reflect.Indirect(reflect.ValueOf(owner)).FieldByName(field).Type()).Elem()
+		for _, key := range keys {
+			id, ok := im.keys[target][key]
+			if !ok {
+				im.conflict(entry, fmt.Sprintf("there is no %s %s", target, key))
+				continue
+			}
+
+			value := reflect.New(values.Type().Elem()).Elem()
+			value.FieldByName("ID").SetUint(id)
+			values = reflect.Append(values, value)
+		}
+
+		if len(im.report.Conflicts) > 0 {
+			// Everything will be rolled back anyway, so only conflicts matter from now on.
+			return nil
+		}
+
+		return im.repository.GetDB().Model(owner).Omit(field + ".*").Association(field).Replace(values.Interface())
+	})
+}
+
+func (im *importer) conflict(entry Entry, message string) {
+	entry.Message = message
+	im.report.Conflicts = append(im.report.Conflicts, entry)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditHandler implements dependency injection for Repository. This controller needs no visibility to database connections.
+type AuditHandler struct {
+	repository database.Repository
+}
+
+// NewAuditHandler constructs a new handler so we don't need to expose its internal fields.
+func NewAuditHandler(r database.Repository) AuditHandler {
+	return AuditHandler{r}
+}
+
+// GetAll audit entries, latest first, filtered by entity_type, entity_id, actor, action, since and until query parameters.
+// Results are paginated with limit and offset.
+func (h *AuditHandler) GetAll(c *gin.Context) {
+	query := h.repository.GetDB().Order("id DESC")
+
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	if entityID := c.Query("entity_id"); entityID != "" {
+		id, err := strconv.ParseUint(entityID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "IDs should be numerical values. Invalid ID received: "+entityID)
+			return
+		}
+
+		query = query.Where("entity_id = ?", id)
+	}
+
+	findAudit(c, query)
+}
+
+// getHistory retrieves every audit entry of the entity with the provided value in path parameter, oldest first.
+func getHistory(c *gin.Context, repository database.Repository, model interface{}) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	db := repository.GetDB()
+	findAudit(c, db.Where("entity_type = ? AND entity_id = ?", database.EntityType(db, model), id).Order("id ASC"))
+}
+
+// findAudit applies the filters shared by every audit route and writes the matching entries.
+func findAudit(c *gin.Context, query *gorm.DB) {
+	if actor := c.Query("actor"); actor != "" {
+		query = query.Where("actor = ?", actor)
+	}
+
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	for param, condition := range map[string]string{"since": "timestamp >= ?", "until": "timestamp < ?"} {
+		if value := c.Query(param); value != "" {
+			timestamp, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, "Timestamps should follow RFC 3339, like 2022-05-30T21:00:00Z. Invalid "+param+" received: "+value)
+				return
+			}
+
+			query = query.Where(condition, timestamp)
+		}
+	}
+
+	limit, offset, ok := parsePage(c, defaultAuditLimit, maxAuditLimit)
+	if !ok {
+		return
+	}
+
+	var entries []database.AuditEntry
+	if err := query.Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		log.Println("Error while executing findAudit: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	respond(c, http.StatusOK, entries)
+}
+
+// parsePage reads limit and offset query parameters, capping limit to the provided maximum.
+func parsePage(c *gin.Context, defaultLimit int, maxLimit int) (limit int, offset int, ok bool) {
+	limit, offset = defaultLimit, 0
+
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, "limit should be a positive number. Invalid limit received: "+value)
+			return 0, 0, false
+		}
+
+		if limit = parsed; limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+
+	if value := c.Query("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, "offset should be zero or a positive number. Invalid offset received: "+value)
+			return 0, 0, false
+		}
+
+		offset = parsed
+	}
+
+	return limit, offset, true
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// adminKey is the gin context key set when the request carries a valid administrator token.
+	adminKey = "admin"
+	// actorKey is the gin context key holding the name of the administrator who owns the token.
+	actorKey = "actor"
+)
+
+// Authenticate flags requests whose "Authorization: Bearer <token>" header matches one of the administrator tokens,
+// which are mapped to the name of their owners. No tokens disables administrator access altogether.
+func Authenticate(adminTokens map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		for adminToken, actor := range adminTokens {
+			if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+				c.Set(adminKey, true)
+				c.Set(actorKey, actor)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdmin aborts requests that were not flagged as coming from an administrator by Authenticate.
+func RequireAdmin(c *gin.Context) {
+	if !isAdmin(c) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "This operation is restricted to administrators.")
+	}
+}
+
+func isAdmin(c *gin.Context) bool {
+	return c.GetBool(adminKey)
+}
+
+// actorOf names who is making the request, so changes can be attributed to them.
+func actorOf(c *gin.Context) string {
+	return c.GetString(actorKey)
+}
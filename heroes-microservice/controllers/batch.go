@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
+)
+
+// maxBatchSize caps how many IDs a single batch can ask for.
+const maxBatchSize = 1000
+
+// Batch holds the entities found by ID, in the requested order, along with the IDs not found.
+type Batch struct {
+	Results interface{} `json:"results"`
+	Missing []uint64    `json:"missing"`
+}
+
+// BatchRequest lists the IDs to fetch, for lists too long to fit ?ids=.
+type BatchRequest struct {
+	IDs []uint64 `json:"ids" binding:"required"`
+}
+
+// postBatch writes the entities with the IDs listed in the request body as a Batch.
+func postBatch(c *gin.Context, repository database.Repository, dest interface{}, fields filter.Fields) {
+	var request BatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if len(request.IDs) > maxBatchSize {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Batches should have at most %d IDs. IDs received: %d", maxBatchSize, len(request.IDs)))
+		return
+	}
+
+	getBatch(c, repository, dest, fields, request.IDs)
+}
+
+// getBatch writes the entities with the provided IDs as a Batch. Repeated IDs are only returned once.
+// Entities left out by ?filter= are missing as well.
+func getBatch(c *gin.Context, repository database.Repository, dest interface{}, fields filter.Fields, ids []uint64) {
+	ids = distinct(ids)
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.FormatUint(id, 10)
+	}
+
+	if len(ids) > 0 && !find(c, repository, dest, fields, filter.Comparison{Field: "id", Operator: filter.In, Values: values}) {
+		return
+	}
+
+	found := reflect.ValueOf(dest).Elem()
+	byID := map[uint64]reflect.Value{}
+	for i := 0; i < found.Len(); i++ {
+		byID[idOf(found.Index(i).Interface())] = found.Index(i)
+	}
+
+	results := reflect.MakeSlice(found.Type(), 0, len(ids))
+	missing := []uint64{}
+	for _, id := range ids {
+		if entity, ok := byID[id]; ok {
+			results = reflect.Append(results, entity)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	shaped, ok := shape(c, results.Interface())
+	if !ok {
+		return
+	}
+
+	respond(c, http.StatusOK, Batch{shaped, missing})
+}
+
+// parseIDs reads the comma separated IDs of ?ids=, up to maxBatchSize.
+func parseIDs(c *gin.Context, values []string) ([]uint64, bool) {
+	if len(values) > maxBatchSize {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Batches should have at most %d IDs. IDs received: %d", maxBatchSize, len(values)))
+		return nil, false
+	}
+
+	ids := make([]uint64, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "IDs should be numerical values. Invalid ID received: "+value)
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, true
+}
+
+func distinct(ids []uint64) []uint64 {
+	seen := map[uint64]bool{}
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
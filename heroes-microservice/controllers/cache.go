@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// CacheHandler exposes repository cache statistics. Only registered when caching is enabled.
+type CacheHandler struct {
+	repository *database.CachedRepository
+}
+
+// NewCacheHandler constructs a new handler so we don't need to expose its internal fields.
+func NewCacheHandler(r *database.CachedRepository) CacheHandler {
+	return CacheHandler{r}
+}
+
+// GetStats returns hit, miss and eviction counters for the repository cache.
+func (h *CacheHandler) GetStats(c *gin.Context) {
+	respond(c, http.StatusOK, h.repository.Stats())
+}
@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/dice"
+)
+
+// defaultCheckDice are rolled by skill checks that don't ask for other dice.
+const defaultCheckDice = "3d6"
+
+// Outcome of a skill check.
+type Outcome string
+
+const (
+	// CriticalSuccess happens when every die kept rolls its highest side, succeeding whatever the target.
+	CriticalSuccess Outcome = "critical_success"
+	// Success happens when the attribute plus the dice reach the target.
+	Success Outcome = "success"
+	// Failure happens when the attribute plus the dice fall short of the target.
+	Failure Outcome = "failure"
+	// CriticalFailure happens when every die kept rolls 1, failing whatever the target.
+	CriticalFailure Outcome = "critical_failure"
+)
+
+// SkillCheckRequest describes who performs a skill, either a character made of a race and a class or raw attributes.
+type SkillCheckRequest struct {
+	RaceID     uint64            `json:"race_id"`
+	ClassID    uint64            `json:"class_id"`
+	Attributes *heroes.Attribute `json:"attributes"`
+	// Attribute added to the dice, one of strength, agility, intelligence or willpower. Not needed by auto skills.
+	Attribute string `json:"attribute"`
+	// Target is the opponent's value for target_plus skills, or the one set by the game master for variable ones.
+	Target *int `json:"target"`
+	// Dice to roll, 3d6 when empty.
+	Dice string `json:"dice"`
+	// Seed reproduces a previous check. Rolls are random when absent.
+	Seed *int64 `json:"seed"`
+}
+
+// SkillCheck is the resolution of a skill performed with the attribute plus dice against the skill difficulty.
+// Auto skills always succeed without rolling.
+type SkillCheck struct {
+	SkillID        uint64                `json:"skill_id"`
+	DifficultyType heroes.DifficultyType `json:"difficulty_type"`
+	Attribute      string                `json:"attribute,omitempty"`
+	AttributeValue int                   `json:"attribute_value"`
+	Roll           *DiceRoll             `json:"roll,omitempty"`
+	// Total is the attribute value plus the dice.
+	Total  int `json:"total"`
+	Target int `json:"target"`
+	// Margin the total beats the target by, negative when short of it.
+	Margin  int     `json:"margin"`
+	Outcome Outcome `json:"outcome"`
+}
+
+// Check resolves the skill in path parameter as performed by the character or attributes in the request body,
+// according to its difficulty type: auto always succeeds, fixed targets its difficulty, target_plus the opponent's
+// value plus its difficulty as modifier, and variable whatever target the game master sets.
+func (h *SkillHandler) Check(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var request SkillCheckRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	var skill heroes.Skill
+	if !h.repository.Expand().FindByID(&skill, id) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("{id: %d, message: \"Resource not found.\"}", id))
+		return
+	}
+
+	check := SkillCheck{SkillID: skill.ID, DifficultyType: skill.DifficultyType}
+	if skill.DifficultyType == heroes.Auto {
+		check.Outcome = Success
+		respond(c, http.StatusOK, check)
+		return
+	}
+
+	target, err := targetOf(skill, request.Target)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attributes, ok := h.attributesOf(c, request)
+	if !ok {
+		return
+	}
+
+	request.Attribute = strings.ToLower(strings.TrimSpace(request.Attribute))
+	value, err := attributeOf(attributes, request.Attribute)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notation := request.Dice
+	if strings.TrimSpace(notation) == "" {
+		notation = defaultCheckDice
+	}
+
+	expression, err := dice.Parse(notation)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid dice: "+err.Error())
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	roll := DiceRoll{seed, dice.NewRoller(seed).Roll(expression)}
+	check.Attribute, check.AttributeValue, check.Roll = request.Attribute, value, &roll
+	check.Total = value + roll.Total
+	check.Target = target
+	check.Margin = check.Total - target
+	check.Outcome = outcomeOf(roll.Result, check.Margin)
+	respond(c, http.StatusOK, check)
+}
+
+// attributesOf sums the race base attributes with the class bonus ones, unless raw attributes are provided instead.
+func (h *SkillHandler) attributesOf(c *gin.Context, request SkillCheckRequest) (heroes.Attribute, bool) {
+	character := request.RaceID > 0 || request.ClassID > 0
+	switch {
+	case request.Attributes != nil && character:
+		c.JSON(http.StatusBadRequest, "Checks are performed either by a character or by raw attributes, not both.")
+		return heroes.Attribute{}, false
+	case request.Attributes != nil:
+		return *request.Attributes, true
+	case request.RaceID == 0 || request.ClassID == 0:
+		c.JSON(http.StatusBadRequest, "Checks should be performed by a character, with race_id and class_id, or by raw attributes.")
+		return heroes.Attribute{}, false
+	}
+
+	var race heroes.Race
+	var class heroes.Class
+	repository := h.repository.Expand()
+	if !repository.FindByID(&race, request.RaceID) {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("No race found with ID %d.", request.RaceID))
+		return heroes.Attribute{}, false
+	}
+
+	if !repository.FindByID(&class, request.ClassID) {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("No class found with ID %d.", request.ClassID))
+		return heroes.Attribute{}, false
+	}
+
+	base, bonus := race.BaseAttributes, class.BonusAttributes
+	return heroes.Attribute{
+		Strength:     base.Strength + bonus.Strength,
+		Agility:      base.Agility + bonus.Agility,
+		Intelligence: base.Intelligence + bonus.Intelligence,
+		Willpower:    base.Willpower + bonus.Willpower,
+	}, true
+}
+
+// attributeOf picks the value of the attribute by its JSON name.
+func attributeOf(attributes heroes.Attribute, name string) (int, error) {
+	switch name {
+	case "strength":
+		return attributes.Strength, nil
+	case "agility":
+		return attributes.Agility, nil
+	case "intelligence":
+		return attributes.Intelligence, nil
+	case "willpower":
+		return attributes.Willpower, nil
+	default:
+		return 0, fmt.Errorf("attribute should be one of strength, agility, intelligence or willpower. Invalid attribute received: %q", name)
+	}
+}
+
+// targetOf is the number the check must reach according to the skill difficulty type.
+func targetOf(skill heroes.Skill, target *int) (int, error) {
+	switch skill.DifficultyType {
+	case heroes.Fixed:
+		difficulty, err := strconv.Atoi(strings.TrimSpace(skill.Difficulty))
+		if err != nil {
+			return 0, fmt.Errorf("%s has a fixed difficulty that is not a number: %q", skill.Name, skill.Difficulty)
+		}
+		return difficulty, nil
+	case heroes.TargetPlus:
+		if target == nil {
+			return 0, fmt.Errorf("%s targets an opponent value, which should be sent as target", skill.Name)
+		}
+
+		// The difficulty holds the modifier added to the opponent value, such as +2, and none when empty.
+		modifier := 0
+		if difficulty := strings.TrimSpace(skill.Difficulty); difficulty != "" {
+			var err error
+			if modifier, err = strconv.Atoi(difficulty); err != nil {
+				return 0, fmt.Errorf("%s has a modifier that is not a number: %q", skill.Name, skill.Difficulty)
+			}
+		}
+		return *target + modifier, nil
+	case heroes.Variable:
+		if target == nil {
+			return 0, fmt.Errorf("%s has a variable difficulty, so the game master should send the target", skill.Name)
+		}
+		return *target, nil
+	default:
+		return 0, fmt.Errorf("%s has an unknown difficulty type: %q", skill.Name, skill.DifficultyType)
+	}
+}
+
+// outcomeOf tells criticals apart from plain successes and failures. Criticals need every die kept to roll the same
+// extreme, so rolls without dice never are. Subtracted dice, whose term totals are negative, work against the roll, so
+// they are left out.
+func outcomeOf(roll dice.Result, margin int) Outcome {
+	highest, lowest, rolled := true, true, false
+	for _, term := range roll.Terms {
+		if term.Total < 0 {
+			continue
+		}
+
+		for _, die := range term.Dice {
+			if !die.Dropped {
+				rolled = true
+				highest = highest && die.Value == die.Sides
+				lowest = lowest && die.Value == 1
+			}
+		}
+	}
+
+	switch {
+	case rolled && highest:
+		return CriticalSuccess
+	case rolled && lowest:
+		return CriticalFailure
+	case margin >= 0:
+		return Success
+	default:
+		return Failure
+	}
+}
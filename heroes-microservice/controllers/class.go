@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
 )
 
 // ClassHandler implements dependency injection for Repository. This controller needs no visibility to database connections.
@@ -21,35 +21,106 @@ func NewClassHandler(r database.Repository) ClassHandler {
 	return ClassHandler{r}
 }
 
-// GetAll instances of this entity.
+// GetAll instances of this entity, optionally filtered by a ?filter= expression over ClassFilters. With ?ids= only the
+// listed ones are returned, in the same order, along with the IDs not found.
 func (h *ClassHandler) GetAll(c *gin.Context) {
-	getAll(c, h.repository, &[]heroes.Class{})
+	getAll(c, h.repository, &[]heroes.Class{}, ClassFilters)
 }
 
-// GetByID the entity with the provided value in path parameter.
+// GetBatch the entities with the IDs listed in the request body, for lists too long to fit ?ids=.
+func (h *ClassHandler) GetBatch(c *gin.Context) {
+	postBatch(c, h.repository, &[]heroes.Class{}, ClassFilters)
+}
+
+// GetByID the entity with the provided value in path parameter, either its ID or slug.
 func (h *ClassHandler) GetByID(c *gin.Context) {
 	getByID(c, h.repository, &heroes.Class{})
 }
 
-// GetByRole retrieve all entities whose role matches the provided value in path parameter.
+// GetByName the entity whose name or slug matches the provided value in path parameter, ignoring case and accents.
+// Responds 404 suggesting similar names otherwise.
+func (h *ClassHandler) GetByName(c *gin.Context) {
+	getByName(c, h.repository, &heroes.Class{})
+}
+
+// Create a new entity from the request body.
+func (h *ClassHandler) Create(c *gin.Context) {
+	create(c, h.repository, &heroes.Class{})
+}
+
+// Update replaces the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *ClassHandler) Update(c *gin.Context) {
+	update(c, h.repository, &heroes.Class{})
+}
+
+// Patch changes only the fields present in the request body. Requires If-Match with the current ETag.
+func (h *ClassHandler) Patch(c *gin.Context) {
+	patch(c, h.repository, &heroes.Class{})
+}
+
+// Delete soft deletes the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *ClassHandler) Delete(c *gin.Context) {
+	deleteByID(c, h.repository, &heroes.Class{})
+}
+
+// GetHistory lists every audit entry of the entity with the provided value in path parameter, oldest first.
+func (h *ClassHandler) GetHistory(c *gin.Context) {
+	getHistory(c, h.repository, &heroes.Class{})
+}
+
+// Restore undeletes the entity with the provided value in path parameter.
+func (h *ClassHandler) Restore(c *gin.Context) {
+	restoreByID(c, h.repository, &heroes.Class{})
+}
+
+// GetByRole retrieve all entities whose role matches the provided value in path parameter. Can be narrowed further with ?filter=.
 func (h *ClassHandler) GetByRole(c *gin.Context) {
-	role := heroes.Role(strings.ToLower(c.Param("role")))
-	getByField(c, h.repository, &[]heroes.Class{}, &heroes.Class{Role: role})
+	role, err := heroes.ParseRole(c.Param("role"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list(c, h.repository, &[]heroes.Class{}, ClassFilters, filter.Comparison{Field: "role", Operator: filter.Eq, Values: []string{string(role)}})
 }
 
-// GetByProficiencies retrives all entities whose proficiencies match the parameters provided.
+// GetByProficiencies retrives all entities whose proficiencies match the parameters provided, ignoring case.
+// The match query parameter chooses whether classes have any (default), all or none of the proficiencies.
 func (h *ClassHandler) GetByProficiencies(c *gin.Context) {
 	var classes []heroes.Class
 	proficiencies, queryParamNotEmpty := c.Request.URL.Query()["proficiencies"]
+	for i, proficiency := range proficiencies {
+		parsed, err := heroes.ParseProficiencyType(proficiency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		proficiencies[i] = string(parsed)
+	}
+
+	match, err := heroes.ParseMatch(c.Query("match"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repository, ok := scoped(c, h.repository)
+	if !ok {
+		return
+	}
+
+	repository, ok = expanded(c, repository, &classes)
+	if !ok {
+		return
+	}
 
 	if queryParamNotEmpty {
-		// rawQuery := "SELECT * from classes c INNER JOIN class_proficiencies cp ON (cp.class_id = c.id) INNER JOIN proficiencies p ON (cp.proficiency_id = p.id) WHERE p.name IN ?"
-		if err := h.repository.GetDB().Model(&classes).Distinct().Joins("INNER JOIN class_proficiencies cp ON (cp.class_id = id)").Joins("INNER JOIN proficiencies p ON (cp.proficiency_id = p.id)").Where("p.name IN ?", proficiencies).Find(&classes).Error; err != nil {
+		if err := database.ClassProficiencies.WhereMatches(repository.GetDB(), match, proficiencies).Find(&classes).Error; err != nil {
 			log.Println("Error while executing getClassesByProficiencies: ", err)
 			c.JSON(http.StatusInternalServerError, fmt.Sprintf("{proficiencies: %s, message: \"Unable to process your request right now. Please check with system administrator.\"}", proficiencies))
 			return
 		}
 	}
 
-	c.IndentedJSON(http.StatusOK, classes)
+	render(c, http.StatusOK, classes)
 }
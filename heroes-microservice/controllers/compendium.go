@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/compendium"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// CompendiumHandler implements dependency injection for Repository, importing and exporting every entity at once.
+type CompendiumHandler struct {
+	repository database.Repository
+}
+
+// NewCompendiumHandler constructs a new handler so we don't need to expose its internal fields.
+func NewCompendiumHandler(r database.Repository) CompendiumHandler {
+	return CompendiumHandler{r}
+}
+
+// Export writes every race, class, skill and proficiency as ?format=json (default), yaml or csv, only the ones of the
+// ?entity= type when provided. CSV holds a single entity type, so it requires one.
+func (h *CompendiumHandler) Export(c *gin.Context) {
+	format, entity, ok := compendiumFormat(c)
+	if !ok {
+		return
+	}
+
+	content, err := compendium.Export(h.repository, entity)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	var body bytes.Buffer
+	if err := compendium.Encode(&body, content, format, entity); err != nil {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	name := entity
+	if name == "" {
+		name = "compendium"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	c.Data(http.StatusOK, format.ContentType(), body.Bytes())
+}
+
+// Import creates and updates entities from the request body, in any format Export writes, all at once or not at all.
+// Conflicts respond 409 with the report. With ?dry_run=true nothing is written, and the report tells what would be.
+func (h *CompendiumHandler) Import(c *gin.Context) {
+	format, entity, ok := compendiumFormat(c)
+	if !ok {
+		return
+	}
+
+	content, err := compendium.Decode(c.Request.Body, format, entity)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid compendium: "+err.Error())
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+	report, err := compendium.Import(h.repository.WithActor(actorOf(c)), content, dryRun)
+	switch {
+	case errors.Is(err, compendium.ErrConflicts) && !dryRun:
+		respond(c, http.StatusConflict, report)
+	case err != nil && !errors.Is(err, compendium.ErrConflicts):
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+	default:
+		respond(c, http.StatusOK, report)
+	}
+}
+
+// compendiumFormat reads the ?format= and ?entity= query parameters.
+func compendiumFormat(c *gin.Context) (compendium.Format, string, bool) {
+	format, err := compendium.ParseFormat(c.Query("format"))
+	if err == nil {
+		err = compendium.CheckEntity(format, c.Query("entity"))
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	return format, c.Query("entity"), true
+}
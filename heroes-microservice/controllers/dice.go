@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/dice"
+)
+
+// DiceRoll is the result of rolling dice, along with the seed that reproduces it.
+type DiceRoll struct {
+	Seed int64 `json:"seed"`
+	dice.Result
+}
+
+// DiceHandler rolls dice, seeding a new roller for every request.
+type DiceHandler struct {
+	seed func() int64
+}
+
+// NewDiceHandler constructs a new handler so we don't need to expose its internal fields.
+func NewDiceHandler() DiceHandler {
+	return DiceHandler{func() int64 { return time.Now().UnixNano() }}
+}
+
+// Roll rolls the dice of ?notation=, such as 2d6+3 or 4d6kh3, answering every die rolled along with the total.
+// Rolls are random unless ?seed= is provided, and the seed used is always answered so any roll can be reproduced.
+func (h *DiceHandler) Roll(c *gin.Context) {
+	notation, ok := rawQuery(c, "notation")
+	if !ok || strings.TrimSpace(notation) == "" {
+		c.JSON(http.StatusBadRequest, "notation should have the dice to roll, such as 2d6+3.")
+		return
+	}
+
+	expression, err := dice.Parse(notation)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid notation: "+err.Error())
+		return
+	}
+
+	seed, ok := h.seedOf(c)
+	if !ok {
+		return
+	}
+
+	respond(c, http.StatusOK, DiceRoll{seed, dice.NewRoller(seed).Roll(expression)})
+}
+
+// seedOf reads ?seed=, or picks a new one when absent.
+func (h *DiceHandler) seedOf(c *gin.Context) (int64, bool) {
+	value, ok := c.GetQuery("seed")
+	if !ok {
+		return h.seed(), true
+	}
+
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "seed should be a numerical value. Invalid seed received: "+value)
+		return 0, false
+	}
+
+	return seed, true
+}
+
+// rawQuery reads a query parameter keeping its plus signs, which query strings otherwise decode as spaces, so
+// notations such as 2d6+3 don't need to be escaped.
+func rawQuery(c *gin.Context, name string) (string, bool) {
+	for _, pair := range strings.Split(c.Request.URL.RawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key != name {
+			continue
+		}
+
+		value, err := url.PathUnescape(value)
+		return value, err == nil
+	}
+
+	return "", false
+}
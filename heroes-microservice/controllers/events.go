@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+)
+
+// heartbeatInterval keeps idle connections from being closed by proxies.
+const heartbeatInterval = 15 * time.Second
+
+// EventsHandler streams change notifications. This controller needs no visibility to database connections.
+type EventsHandler struct {
+	feed *events.Feed
+}
+
+// NewEventsHandler constructs a new handler so we don't need to expose its internal fields.
+func NewEventsHandler(feed *events.Feed) EventsHandler {
+	return EventsHandler{feed}
+}
+
+// Stream changes as Server-Sent Events named after the entity and action, like "skills.update".
+// Clients resume from the Last-Event-ID header (or last_event_id query parameter) and may restrict the stream
+// to some entities with ?entity=races&entity=classes.
+func (h *EventsHandler) Stream(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var after uint64
+	if lastEventID != "" {
+		var err error
+		if after, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, "Event IDs should be numerical values. Invalid ID received: "+lastEventID)
+			return
+		}
+	}
+
+	replay, live, err := h.feed.Subscribe(after, lastEventID != "")
+	if errors.Is(err, events.ErrTooFarBehind) {
+		c.JSON(http.StatusGone, fmt.Sprintf("Unable to resume from event %d, as more than %d events happened since. Reload the resources and reconnect without Last-Event-ID.", after, events.MaxReplay))
+		return
+	}
+
+	if err != nil {
+		log.Println("Error while subscribing to events: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+	defer h.feed.Unsubscribe(live)
+
+	entities := map[string]bool{}
+	for _, entity := range c.QueryArray("entity") {
+		entities[entity] = true
+	}
+
+	send := func(event events.Event) {
+		if len(entities) == 0 || entities[event.EntityType] {
+			c.Render(-1, sse.Event{Id: strconv.FormatUint(event.ID, 10), Event: fmt.Sprintf("%s.%s", event.EntityType, event.Action), Data: event})
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	for _, event := range replay {
+		send(event)
+	}
+
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, open := <-live:
+			if !open {
+				return
+			}
+			send(event)
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// maxExpandDepth caps how many associations deep ?expand= can go, as every level costs another query.
+const maxExpandDepth = 3
+
+// expanded preloads the associations listed in ?expand=, such as recommended_classes.proficiencies, through the
+// repository. Associations are named after their JSON fields, in snake case. Without ?expand= the repository defaults
+// are kept: every direct association for a single entity and none for lists.
+// ?fields= is validated as well, so invalid requests fail before querying.
+func expanded(c *gin.Context, repository database.Repository, dest interface{}) (database.Repository, bool) {
+	if _, ok := selectedFields(c, dest); !ok {
+		return nil, false
+	}
+
+	value, ok := c.GetQuery("expand")
+	if !ok {
+		return repository, true
+	}
+
+	var associations []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+
+		association, err := associationPath(elemType(reflect.TypeOf(dest)), path)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "Invalid expand: "+err.Error())
+			return nil, false
+		}
+		associations = append(associations, association)
+	}
+
+	return repository.Expand(associations...), true
+}
+
+// associationPath translates a dotted path of JSON names into the gorm association path of t.
+func associationPath(t reflect.Type, path string) (string, error) {
+	names := strings.Split(path, ".")
+	if len(names) > maxExpandDepth {
+		return "", fmt.Errorf("%s is deeper than %d associations", path, maxExpandDepth)
+	}
+
+	fields := make([]string, len(names))
+	for i, name := range names {
+		associations := map[string]reflect.StructField{}
+		for _, field := range jsonFields(t) {
+			if field.Type.Kind() == reflect.Slice && elemType(field.Type).Kind() == reflect.Struct {
+				associations[snakeCase(field.Name)] = field
+			}
+		}
+
+		field, ok := associations[name]
+		if !ok {
+			return "", fmt.Errorf("%s has no association %s. Should be one of %s", t.Name(), name, strings.Join(sortedKeys(associations), ", "))
+		}
+
+		fields[i] = field.Name
+		t = elemType(field.Type)
+	}
+
+	return strings.Join(fields, "."), nil
+}
+
+// shape keeps only the fields listed in ?fields= of the value, which is returned as is without ?fields=.
+// Responses are only written when it fails.
+func shape(c *gin.Context, value interface{}) (interface{}, bool) {
+	selection, ok := selectedFields(c, value)
+	if !ok || selection == nil {
+		return value, ok
+	}
+
+	decoded, err := generic(value)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return nil, false
+	}
+
+	return selection.apply(decoded), true
+}
+
+// selectedFields parses ?fields= for values of the same type as dest. The fieldset is nil when every field is selected.
+func selectedFields(c *gin.Context, dest interface{}) (fieldset, bool) {
+	fields, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, true
+	}
+
+	selection := fieldset{}
+	for _, path := range strings.Split(fields, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			if err := selection.add(elemType(reflect.TypeOf(dest)), strings.Split(path, ".")); err != nil {
+				c.JSON(http.StatusBadRequest, "Invalid fields: "+err.Error())
+				return nil, false
+			}
+		}
+	}
+
+	if len(selection) == 0 {
+		return nil, true
+	}
+
+	return selection, true
+}
+
+// fieldset is a tree of selected JSON fields. Fields selected as a whole have no children.
+type fieldset map[string]fieldset
+
+func (f fieldset) add(t reflect.Type, path []string) error {
+	fields := map[string]reflect.StructField{}
+	for _, field := range jsonFields(t) {
+		fields[jsonName(field)] = field
+	}
+
+	field, ok := fields[path[0]]
+	if !ok {
+		return fmt.Errorf("%s has no field %s. Should be one of %s", t.Name(), path[0], strings.Join(sortedKeys(fields), ", "))
+	}
+
+	child, selected := f[path[0]]
+	if len(path) == 1 {
+		// Selecting the whole field overrides selections of its nested fields.
+		f[path[0]] = nil
+		return nil
+	}
+
+	if selected && child == nil {
+		return nil
+	}
+
+	if elemType(field.Type).Kind() != reflect.Struct {
+		return fmt.Errorf("%s has no nested fields", path[0])
+	}
+
+	if child == nil {
+		child = fieldset{}
+		f[path[0]] = child
+	}
+
+	return child.add(elemType(field.Type), path[1:])
+}
+
+func (f fieldset) apply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = f.apply(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		selected := map[string]interface{}{}
+		for name, child := range f {
+			if field, ok := v[name]; ok {
+				if child == nil {
+					selected[name] = field
+				} else {
+					selected[name] = child.apply(field)
+				}
+			}
+		}
+		return selected
+	default:
+		return value
+	}
+}
+
+// jsonFields lists the JSON encoded fields of the struct type, including the ones promoted from embedded structs.
+func jsonFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(field.Type)...)
+		} else if field.IsExported() && jsonName(field) != "-" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func jsonName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
+// elemType unwraps pointers and slices down to the entity type.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// snakeCase turns Go field names into snake case, such as RecommendedClasses into recommended_classes.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func sortedKeys(fields map[string]reflect.StructField) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
+)
+
+// Filterable fields of each entity, named after their JSON fields. Nested attributes use a dot, like base_attributes.strength.
+var (
+	RaceFilters = filter.Fields{
+		"id":                           filter.NumberField("id"),
+		"name":                         filter.TextField("name"),
+		"description":                  filter.TextField("description"),
+		"base_attributes.strength":     filter.NumberField("base_strength"),
+		"base_attributes.agility":      filter.NumberField("base_agility"),
+		"base_attributes.intelligence": filter.NumberField("base_intelligence"),
+		"base_attributes.willpower":    filter.NumberField("base_willpower"),
+		"version":                      filter.NumberField("version"),
+	}
+
+	ClassFilters = filter.Fields{
+		"id":                            filter.NumberField("id"),
+		"name":                          filter.TextField("name"),
+		"description":                   filter.TextField("description"),
+		"role":                          filter.EnumField("role", heroes.ParseRole),
+		"bonus_attributes.strength":     filter.NumberField("bonus_strength"),
+		"bonus_attributes.agility":      filter.NumberField("bonus_agility"),
+		"bonus_attributes.intelligence": filter.NumberField("bonus_intelligence"),
+		"bonus_attributes.willpower":    filter.NumberField("bonus_willpower"),
+		"version":                       filter.NumberField("version"),
+	}
+
+	SkillFilters = filter.Fields{
+		"id":                filter.NumberField("id"),
+		"name":              filter.TextField("name"),
+		"description":       filter.TextField("description"),
+		"bonus":             filter.TextField("bonus"),
+		"mana":              filter.TextField("mana"),
+		"difficulty":        filter.TextField("difficulty"),
+		"difficulty_type":   filter.EnumField("difficulty_type", heroes.ParseDifficultyType),
+		"activation":        filter.EnumField("activation", heroes.ParseActivation),
+		"source":            filter.EnumField("source", heroes.ParseSource),
+		"type":              filter.EnumField("type", heroes.ParseSkillType),
+		"level_requirement": filter.EnumField("level_requirement", heroes.ParseLevelRequirement),
+		"observations":      filter.TextField("observations"),
+		"version":           filter.NumberField("version"),
+	}
+
+	ProficiencyFilters = filter.Fields{
+		"id":               filter.NumberField("id"),
+		"proficiency_type": filter.EnumField("name", heroes.ParseProficiencyType),
+		"version":          filter.NumberField("version"),
+	}
+)
@@ -1,40 +1,412 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
 )
 
-func getAll(c *gin.Context, repository database.Repository, dest interface{}) {
-	if repository.FindAll(dest) {
-		c.IndentedJSON(http.StatusOK, dest)
+// maxListLimit caps how many entities a single page of a list holds.
+const maxListLimit = 1000
+
+// getAll writes every entity, or only the ones listed in ?ids= as a Batch.
+func getAll(c *gin.Context, repository database.Repository, dest interface{}, fields filter.Fields) {
+	if value, ok := c.GetQuery("ids"); ok {
+		ids, ok := parseIDs(c, strings.Split(value, ","))
+		if !ok {
+			return
+		}
+
+		getBatch(c, repository, dest, fields, ids)
+		return
+	}
+
+	list(c, repository, dest, fields, nil)
+}
+
+// list writes every entity matching both the base condition and the ?filter= expression, when present.
+// Only a page of them is written when limit or offset are present.
+func list(c *gin.Context, repository database.Repository, dest interface{}, fields filter.Fields, base filter.Node) {
+	repository, ok := paged(c, repository)
+	if !ok {
+		return
+	}
+
+	if find(c, repository, dest, fields, base) {
+		render(c, http.StatusOK, dest)
+	}
+}
+
+// paged narrows the repository to the page asked for through limit and offset. Lists aren't paginated without them.
+func paged(c *gin.Context, repository database.Repository) (database.Repository, bool) {
+	if c.Query("limit") == "" && c.Query("offset") == "" {
+		return repository, true
+	}
+
+	limit, offset, ok := parsePage(c, -1, maxListLimit)
+	if !ok {
+		return nil, false
+	}
+
+	return repository.Page(limit, offset), true
+}
+
+// find loads every entity matching both the base condition and the ?filter= expression into dest.
+// Responses are only written when it fails.
+func find(c *gin.Context, repository database.Repository, dest interface{}, fields filter.Fields, base filter.Node) bool {
+	repository, ok := scoped(c, repository)
+	if !ok {
+		return false
+	}
+
+	repository, ok = expanded(c, repository, dest)
+	if !ok {
+		return false
+	}
+
+	node := base
+	if expression := c.Query("filter"); expression != "" {
+		parsed, err := filter.Parse(expression)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "Invalid filter: "+err.Error())
+			return false
+		}
+		node = filter.AndAll(base, parsed)
+	}
+
+	found := false
+	if node == nil {
+		found = repository.FindAll(dest)
 	} else {
+		condition, err := fields.Clause(node)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "Invalid filter: "+err.Error())
+			return false
+		}
+		found = repository.FindByField(dest, condition)
+	}
+
+	if !found {
 		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
 	}
+
+	return found
 }
 
+// getByID writes the entity with the id path parameter. Named entities can also be found by slug instead.
 func getByID(c *gin.Context, repository database.Repository, dest interface{}) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
-	if err != nil {
-		c.IndentedJSON(http.StatusBadRequest, "IDs should be numerical values. Invalid ID received: "+c.Param("id"))
+	if _, err := strconv.ParseUint(c.Param("id"), 10, 64); err != nil {
+		if _, ok := dest.(named); ok {
+			getBySlug(c, repository, dest)
+			return
+		}
+	}
+
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	repository, ok = scoped(c, repository)
+	if !ok {
+		return
+	}
+
+	repository, ok = expanded(c, repository, dest)
+	if !ok {
 		return
 	}
 
 	if repository.FindByID(dest, id) {
-		c.IndentedJSON(http.StatusOK, dest)
+		setETag(c, dest)
+		render(c, http.StatusOK, dest)
 	} else {
 		c.JSON(http.StatusNotFound, fmt.Sprintf("{id: %d, message: \"Resource not found.\"}", id))
 	}
 }
 
-func getByField(c *gin.Context, repository database.Repository, dest interface{}, query interface{}) {
-	if repository.FindByField(dest, query) {
-		c.IndentedJSON(http.StatusOK, dest)
+// create inserts the entity of the request body. Its ID, version and timestamps are always set by the server, while
+// associations are rejected, as they are never written along with the entity.
+func create(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository = repository.WithActor(actorOf(c))
+
+	if err := c.ShouldBindJSON(dest); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if rejectAssociations(c, associationsOf(dest)) {
+		return
+	}
+	clearServerFields(dest)
+
+	if err := repository.Create(dest); err != nil {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	setETag(c, dest)
+	render(c, http.StatusCreated, dest)
+}
+
+// update replaces every column of the entity, as long as If-Match still holds its current version.
+// Associations are rejected, the same as when creating.
+func update(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository = repository.WithActor(actorOf(c))
+
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := c.ShouldBindJSON(dest); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if rejectAssociations(c, associationsOf(dest)) {
+		return
+	}
+
+	if err := repository.Update(dest, id, version); err != nil {
+		writeError(c, id, err)
+		return
+	}
+
+	getByID(c, repository, newOf(dest))
+}
+
+// patch merges the request body into the current entity before updating it, as long as If-Match still holds its current version.
+// Associations are rejected, the same as when creating.
+func patch(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository = repository.WithActor(actorOf(c))
+
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var changes map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&changes); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if rejectAssociations(c, associationNames(dest, changes)) {
+		return
+	}
+
+	if !repository.FindByID(dest, id) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("{id: %d, message: \"Resource not found.\"}", id))
+		return
+	}
+
+	// Merging through JSON keeps the entity returned by the repository untouched, as it might be cached.
+	merged, err := mergeJSON(dest, changes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := repository.Update(merged, id, version); err != nil {
+		writeError(c, id, err)
+		return
+	}
+
+	getByID(c, repository, newOf(dest))
+}
+
+func deleteByID(c *gin.Context, repository database.Repository, model interface{}) {
+	repository = repository.WithActor(actorOf(c))
+
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.Delete(model, id, version); err != nil {
+		writeError(c, id, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func restoreByID(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository = repository.WithActor(actorOf(c))
+
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.Restore(dest, id); err != nil {
+		writeError(c, id, err)
+		return
+	}
+
+	getByID(c, repository, newOf(dest))
+}
+
+func parseID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, "IDs should be numerical values. Invalid ID received: "+c.Param("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// scoped widens the repository to soft deleted records when an administrator asks for ?include_deleted=true.
+func scoped(c *gin.Context, repository database.Repository) (database.Repository, bool) {
+	if c.Query("include_deleted") != "true" {
+		return repository, true
+	}
+
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, "Only administrators can include deleted resources.")
+		return nil, false
+	}
+
+	return repository.Unscoped(), true
+}
+
+// ifMatch reads the entity version expected by the client from the If-Match header, as sent back from ETag.
+func ifMatch(c *gin.Context) (uint64, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, "If-Match header is required. Use the ETag received when reading the resource.")
+		return 0, false
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusPreconditionFailed, "If-Match header does not match any version: "+header)
+		return 0, false
+	}
+
+	return version, true
+}
+
+func setETag(c *gin.Context, entity interface{}) {
+	if v, ok := entity.(interface{ GetVersion() uint64 }); ok {
+		c.Header("ETag", fmt.Sprintf(`"%d"`, v.GetVersion()))
+	}
+}
+
+// newOf allocates a new zero value of the same type the provided pointer points to.
+func newOf(dest interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(dest).Elem()).Interface()
+}
+
+// serverFields are only ever set by the server, whatever clients send.
+var serverFields = []string{"ID", "Version", "CreatedAt", "UpdatedAt", "DeletedAt"}
+
+// clearServerFields zeroes the serverFields the entity pointed to has.
+func clearServerFields(entity interface{}) {
+	value := reflect.ValueOf(entity).Elem()
+	for _, name := range serverFields {
+		if field := value.FieldByName(name); field.IsValid() && field.CanSet() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
+
+// rejectAssociations answers 400 when the request body sets any association, as they are never written along with the entity.
+func rejectAssociations(c *gin.Context, associations []string) bool {
+	if len(associations) == 0 {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, "Associations can't be written along with resources. Remove them from the request body: "+strings.Join(associations, ", "))
+	return true
+}
+
+// isAssociation tells whether the field holds associated entities, such as starting_skills.
+func isAssociation(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Slice && elemType(field.Type).Kind() == reflect.Struct
+}
+
+// associationsOf lists the JSON names of the associations set in the entity pointed to, such as starting_skills.
+func associationsOf(entity interface{}) []string {
+	value := reflect.ValueOf(entity).Elem()
+	var associations []string
+	for _, field := range jsonFields(value.Type()) {
+		if isAssociation(field) && value.FieldByName(field.Name).Len() > 0 {
+			associations = append(associations, jsonName(field))
+		}
+	}
+
+	return associations
+}
+
+// associationNames lists the JSON names of the associations of the entity pointed to that are keys of changes.
+func associationNames(entity interface{}, changes map[string]json.RawMessage) []string {
+	var associations []string
+	for _, field := range jsonFields(reflect.TypeOf(entity).Elem()) {
+		if _, ok := changes[jsonName(field)]; ok && isAssociation(field) {
+			associations = append(associations, jsonName(field))
+		}
+	}
+
+	return associations
+}
+
+// mergeJSON applies the provided JSON fields on top of a copy of entity, returning a new value of the same type.
+func mergeJSON(entity interface{}, changes map[string]json.RawMessage) (interface{}, error) {
+	current, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return nil, err
+	}
+
+	for field, value := range changes {
+		fields[field] = value
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newOf(entity)
+	return result, json.Unmarshal(merged, result)
+}
+
+func writeError(c *gin.Context, id uint64, err error) {
+	if errors.Is(err, database.ErrNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("{id: %d, message: \"Resource not found.\"}", id))
+	} else if errors.Is(err, database.ErrVersionMismatch) {
+		c.JSON(http.StatusPreconditionFailed, fmt.Sprintf("{id: %d, message: \"Resource was changed by someone else. Reload it and try again.\"}", id))
 	} else {
-		c.JSON(http.StatusInternalServerError, fmt.Sprintf("{field: %s, message: \"Resource not found.\"}", query))
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
 	}
 }
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/graph"
+)
+
+// GraphQLHandler serves GraphQL queries over the heroes domain, so clients fetch nested associations in one round trip.
+type GraphQLHandler struct {
+	repository database.Repository
+	schema     graphql.Schema
+}
+
+// graphQLRequest is the usual GraphQL over HTTP payload.
+type graphQLRequest struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewGraphQLHandler constructs a new handler so we don't need to expose its internal fields.
+func NewGraphQLHandler(r database.Repository) GraphQLHandler {
+	schema, err := graph.NewSchema(r)
+	if err != nil {
+		log.Panicf("Some error occurred while building the GraphQL schema. Err: %s", err)
+	}
+
+	return GraphQLHandler{r, schema}
+}
+
+// Query executes the query sent either as JSON body (POST) or as query parameters (GET).
+// Errors are reported along with partial data with status 200, as GraphQL clients expect.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var request graphQLRequest
+	var err error
+	if c.Request.Method == http.MethodGet {
+		err = c.ShouldBindQuery(&request)
+	} else {
+		err = c.ShouldBindJSON(&request)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	loader := graph.NewLoader(h.repository.GetDB())
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        graph.WithLoader(c.Request.Context(), loader),
+	})
+
+	c.JSON(http.StatusOK, result)
+}
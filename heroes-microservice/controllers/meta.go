@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// MetaHandler describes the API vocabulary, so clients don't need to hard-code it.
+type MetaHandler struct {
+	enums []heroes.Enum
+}
+
+// NewMetaHandler constructs a new handler so we don't need to expose its internal fields.
+func NewMetaHandler() MetaHandler {
+	return MetaHandler{heroes.Enums()}
+}
+
+// GetEnums lists every enum along with its allowed values, labels and descriptions.
+func (h *MetaHandler) GetEnums(c *gin.Context) {
+	respond(c, http.StatusOK, h.enums)
+}
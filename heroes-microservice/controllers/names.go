@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/search"
+)
+
+// named entities can be found by slug or name besides their IDs, such as *heroes.Race.
+type named interface {
+	GetName() string
+	GetSlug() string
+}
+
+// getBySlug writes the entity with the slug in the id path parameter, such as /races/high-elf.
+func getBySlug(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository, ok := scoped(c, repository)
+	if !ok {
+		return
+	}
+
+	// The slug only resolves the ID, so associations are loaded as usual by FindByID.
+	found := newOf(dest)
+	if !repository.FindByField(found, map[string]interface{}{"slug": heroes.Slugify(c.Param("id"))}) {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	if id := idOf(found); id > 0 {
+		findByID(c, repository, dest, id)
+		return
+	}
+
+	notFoundByName(c, repository, dest, c.Param("id"))
+}
+
+// getByName writes the entity whose name or slug matches the name path parameter, ignoring case and accents.
+// Names and slugs are looked up by the database first. Only when none matches every entity is loaded, both to compare
+// names whose accents the database keeps and to suggest the closest ones in 404.
+func getByName(c *gin.Context, repository database.Repository, dest interface{}) {
+	repository, ok := scoped(c, repository)
+	if !ok {
+		return
+	}
+
+	name := c.Param("name")
+	found := newOf(dest)
+	if err := repository.GetDB().Where("slug = ? OR LOWER(name) = ?", heroes.Slugify(name), heroes.Fold(name)).Order("id").Limit(1).Find(found).Error; err != nil {
+		log.Println("Error while executing getByName: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	if id := idOf(found); id > 0 {
+		findByID(c, repository, dest, id)
+		return
+	}
+
+	entities, ok := findAllLike(repository, dest)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	for _, entity := range entities {
+		if heroes.Fold(entity.GetName()) == heroes.Fold(name) {
+			findByID(c, repository, dest, idOf(entity))
+			return
+		}
+	}
+
+	writeNotFoundByName(c, name, entities)
+}
+
+// findByID writes the entity found by name or slug, expanding its associations as requested.
+func findByID(c *gin.Context, repository database.Repository, dest interface{}, id uint64) {
+	repository, ok := expanded(c, repository, dest)
+	if !ok {
+		return
+	}
+
+	if !repository.FindByID(dest, id) {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	setETag(c, dest)
+	render(c, http.StatusOK, dest)
+}
+
+// notFoundByName writes 404 suggesting names of entities of the same type as dest close to the one requested.
+func notFoundByName(c *gin.Context, repository database.Repository, dest interface{}, name string) {
+	entities, ok := findAllLike(repository, dest)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	writeNotFoundByName(c, name, entities)
+}
+
+func writeNotFoundByName(c *gin.Context, name string, entities []named) {
+	names := make([]string, len(entities))
+	for i, entity := range entities {
+		names[i] = entity.GetName()
+	}
+
+	message := "Resource not found."
+	if suggestions := search.Suggest(name, names); len(suggestions) > 0 {
+		message += " Did you mean " + orList(suggestions) + "?"
+	}
+
+	c.JSON(http.StatusNotFound, fmt.Sprintf("{name: %q, message: %q}", name, message))
+}
+
+// findAllLike finds every entity of the same type as dest.
+func findAllLike(repository database.Repository, dest interface{}) ([]named, bool) {
+	all := reflect.New(reflect.SliceOf(reflect.TypeOf(dest).Elem()))
+	if !repository.FindAll(all.Interface()) {
+		return nil, false
+	}
+
+	entities := make([]named, all.Elem().Len())
+	for i := range entities {
+		entities[i] = all.Elem().Index(i).Addr().Interface().(named)
+	}
+
+	return entities, true
+}
+
+func idOf(entity interface{}) uint64 {
+	return reflect.Indirect(reflect.ValueOf(entity)).FieldByName("ID").Uint()
+}
+
+// orList joins values as in "Elf, Dwarf or Goblin".
+func orList(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
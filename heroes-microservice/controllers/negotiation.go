@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	mimeJSON    = "application/json"
+	mimeYAML    = "application/yaml"
+	mimeCSV     = "text/csv"
+	mimeMsgPack = "application/msgpack"
+)
+
+// MediaTypes lists every media type responses are negotiated into through the Accept header, JSON being the default.
+var MediaTypes = []string{mimeJSON, mimeYAML, mimeCSV, mimeMsgPack}
+
+// mediaTypeAliases are the other names clients know the same media types by.
+var mediaTypeAliases = map[string]string{
+	"application/x-yaml":    mimeYAML,
+	"text/yaml":             mimeYAML,
+	"application/x-msgpack": mimeMsgPack,
+}
+
+// render writes the value in the media type negotiated through Accept, keeping only the fields listed in ?fields= when
+// present, such as name,base_attributes.strength,starting_skills.name. Lists keep the fields of each of their entities.
+func render(c *gin.Context, status int, value interface{}) {
+	if shaped, ok := shape(c, value); ok {
+		respond(c, status, shaped)
+	}
+}
+
+// respond writes the value in the media type negotiated through Accept: compact JSON by default, indented with
+// ?pretty, YAML, CSV with a row per entity or MessagePack. Every format goes through JSON first, so they all share the
+// same field names.
+func respond(c *gin.Context, status int, value interface{}) {
+	c.Header("Vary", "Accept")
+
+	offered := append(append([]string{}, MediaTypes...), sortedAliases()...)
+	mediaType := c.NegotiateFormat(offered...)
+	if alias, ok := mediaTypeAliases[mediaType]; ok {
+		mediaType = alias
+	}
+
+	if mediaType == mimeJSON {
+		if pretty(c) {
+			c.IndentedJSON(status, value)
+		} else {
+			c.JSON(status, value)
+		}
+		return
+	}
+
+	if mediaType == "" {
+		c.JSON(http.StatusNotAcceptable, "Unable to respond in the Accept header media types. Should be one of "+strings.Join(MediaTypes, ", "))
+		return
+	}
+
+	content, err := encode(mediaType, value)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	if mediaType != mimeMsgPack {
+		mediaType += "; charset=utf-8"
+	}
+	c.Data(status, mediaType, content)
+}
+
+// pretty tells whether ?pretty asks for indented JSON, as in ?pretty or ?pretty=true.
+func pretty(c *gin.Context) bool {
+	value, ok := c.GetQuery("pretty")
+	return ok && value != "false"
+}
+
+func encode(mediaType string, value interface{}) ([]byte, error) {
+	decoded, err := generic(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaType {
+	case mimeYAML:
+		return yaml.Marshal(decoded)
+	case mimeMsgPack:
+		// Canonical encoding sorts map keys, so the same value always gives the same bytes.
+		var handle codec.MsgpackHandle
+		handle.Canonical = true
+
+		var content []byte
+		err := codec.NewEncoderBytes(&content, &handle).Encode(decoded)
+		return content, err
+	default:
+		return encodeCSV(decoded, columnOrder(elemType(reflect.TypeOf(value)), ""))
+	}
+}
+
+// generic converts the value into the maps, lists and scalars its JSON is decoded into. Whole numbers are kept as
+// integers rather than floats, so IDs stay IDs in every format.
+func generic(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return numbers(decoded), nil
+}
+
+func numbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = numbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = numbers(item)
+		}
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer
+		}
+		float, _ := v.Float64()
+		return float
+	}
+
+	return value
+}
+
+// encodeCSV writes a row per entity of lists, or a single row otherwise. Nested fields have their own columns, such
+// as base_attributes.strength, and associations are listed by name within their cell.
+func encodeCSV(value interface{}, order []string) ([]byte, error) {
+	rows, ok := value.([]interface{})
+	if !ok {
+		rows = []interface{}{value}
+	}
+
+	cells := make([]map[string]string, len(rows))
+	present := map[string]bool{}
+	for i, row := range rows {
+		cells[i] = map[string]string{}
+		flatten(cells[i], "", row)
+		for column := range cells[i] {
+			present[column] = true
+		}
+	}
+
+	// Columns follow the order of the fields in the entity, while the ones it doesn't tell come last, sorted.
+	var columns []string
+	for _, column := range order {
+		if present[column] {
+			columns = append(columns, column)
+			delete(present, column)
+		}
+	}
+	remaining := make([]string, 0, len(present))
+	for column := range present {
+		remaining = append(remaining, column)
+	}
+	sort.Strings(remaining)
+	columns = append(columns, remaining...)
+
+	var content bytes.Buffer
+	w := csv.NewWriter(&content)
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+
+	for _, row := range cells {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return content.Bytes(), w.Error()
+}
+
+func flatten(cells map[string]string, column string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if column != "" {
+			column += "."
+		}
+		for key, item := range v {
+			flatten(cells, column+key, item)
+		}
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = cellOf(item)
+		}
+		cells[column] = strings.Join(items, ", ")
+	default:
+		cells[column] = cellOf(v)
+	}
+}
+
+// cellOf writes scalars as they are and entities by name, falling back to JSON for anything else.
+func cellOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		if name, ok := v["name"]; ok {
+			return fmt.Sprint(name)
+		}
+	case []interface{}:
+	default:
+		return fmt.Sprint(v)
+	}
+
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// columnOrder lists the CSV columns of the struct type in the order of its fields, nested ones after their parent.
+func columnOrder(t reflect.Type, prefix string) []string {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []string
+	for _, field := range jsonFields(t) {
+		name := prefix + jsonName(field)
+		columns = append(columns, name)
+		if field.Type.Kind() == reflect.Struct {
+			columns = append(columns, columnOrder(field.Type, name+".")...)
+		}
+	}
+
+	return columns
+}
+
+func sortedAliases() []string {
+	aliases := make([]string, 0, len(mediaTypeAliases))
+	for alias := range mediaTypeAliases {
+		aliases = append(aliases, alias)
+	}
+
+	sort.Strings(aliases)
+	return aliases
+}
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/openapi"
+)
+
+// OpenAPIHandler serves the OpenAPI document describing this API.
+type OpenAPIHandler struct {
+	document *openapi.Document
+}
+
+// NewOpenAPIHandler constructs a new handler so we don't need to expose its internal fields.
+// The document may still be filled in after the handler is registered.
+func NewOpenAPIHandler(document *openapi.Document) OpenAPIHandler {
+	return OpenAPIHandler{document}
+}
+
+// Get the OpenAPI document.
+func (h *OpenAPIHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, h.document)
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// ProficiencyHandler implements dependency injection for Repository. This controller needs no visibility to database connections.
+type ProficiencyHandler struct {
+	repository database.Repository
+}
+
+// NewProficiencyHandler constructs a new handler so we don't need to expose its internal fields.
+func NewProficiencyHandler(r database.Repository) ProficiencyHandler {
+	return ProficiencyHandler{r}
+}
+
+// GetAll instances of this entity, optionally filtered by a ?filter= expression over ProficiencyFilters.
+func (h *ProficiencyHandler) GetAll(c *gin.Context) {
+	getAll(c, h.repository, &[]heroes.Proficiency{}, ProficiencyFilters)
+}
+
+// GetByID the entity with the provided value in path parameter.
+func (h *ProficiencyHandler) GetByID(c *gin.Context) {
+	getByID(c, h.repository, &heroes.Proficiency{})
+}
+
+// GetClasses retrieves every class having the proficiency with the provided value in path parameter.
+func (h *ProficiencyHandler) GetClasses(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	repository, ok := scoped(c, h.repository)
+	if !ok {
+		return
+	}
+
+	if !repository.FindByID(&heroes.Proficiency{}, id) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("{id: %d, message: \"Resource not found.\"}", id))
+		return
+	}
+
+	var classes []heroes.Class
+	repository, ok = expanded(c, repository, &classes)
+	if !ok {
+		return
+	}
+
+	if err := repository.GetDB().Joins("INNER JOIN class_proficiencies cp ON (cp.class_id = classes.id)").Where("cp.proficiency_id = ?", id).Order("classes.id ASC").Find(&classes).Error; err != nil {
+		log.Println("Error while executing getProficiencyClasses: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	render(c, http.StatusOK, classes)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
@@ -21,33 +20,88 @@ func NewRaceHandler(r database.Repository) RaceHandler {
 	return RaceHandler{r}
 }
 
-// GetAll instances of this entity.
+// GetAll instances of this entity, optionally filtered by a ?filter= expression over RaceFilters. With ?ids= only the
+// listed ones are returned, in the same order, along with the IDs not found.
 func (h *RaceHandler) GetAll(c *gin.Context) {
-	getAll(c, h.repository, &[]heroes.Race{})
+	getAll(c, h.repository, &[]heroes.Race{}, RaceFilters)
 }
 
-// GetByID the entity with the provided value in path parameter.
+// GetBatch the entities with the IDs listed in the request body, for lists too long to fit ?ids=.
+func (h *RaceHandler) GetBatch(c *gin.Context) {
+	postBatch(c, h.repository, &[]heroes.Race{}, RaceFilters)
+}
+
+// GetByID the entity with the provided value in path parameter, either its ID or slug.
 func (h *RaceHandler) GetByID(c *gin.Context) {
 	getByID(c, h.repository, &heroes.Race{})
 }
 
-// GetByRecommendedClasses retrives all entities whose recommended classes match the parameters provided.
+// GetByName the entity whose name or slug matches the provided value in path parameter, ignoring case and accents.
+// Responds 404 suggesting similar names otherwise.
+func (h *RaceHandler) GetByName(c *gin.Context) {
+	getByName(c, h.repository, &heroes.Race{})
+}
+
+// Create a new entity from the request body.
+func (h *RaceHandler) Create(c *gin.Context) {
+	create(c, h.repository, &heroes.Race{})
+}
+
+// Update replaces the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *RaceHandler) Update(c *gin.Context) {
+	update(c, h.repository, &heroes.Race{})
+}
+
+// Patch changes only the fields present in the request body. Requires If-Match with the current ETag.
+func (h *RaceHandler) Patch(c *gin.Context) {
+	patch(c, h.repository, &heroes.Race{})
+}
+
+// Delete soft deletes the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *RaceHandler) Delete(c *gin.Context) {
+	deleteByID(c, h.repository, &heroes.Race{})
+}
+
+// GetHistory lists every audit entry of the entity with the provided value in path parameter, oldest first.
+func (h *RaceHandler) GetHistory(c *gin.Context) {
+	getHistory(c, h.repository, &heroes.Race{})
+}
+
+// Restore undeletes the entity with the provided value in path parameter.
+func (h *RaceHandler) Restore(c *gin.Context) {
+	restoreByID(c, h.repository, &heroes.Race{})
+}
+
+// GetByRecommendedClasses retrives all entities whose recommended classes match the parameters provided, ignoring case.
+// The match query parameter chooses whether races recommend any (default), all or none of the classes.
 func (h *RaceHandler) GetByRecommendedClasses(c *gin.Context) {
 	var races []heroes.Race
 	queryClasses, queryParamNotEmpty := c.Request.URL.Query()["classes"]
 
-	if queryParamNotEmpty {
-		// Lowercasing params because SQL's IN clause is case sensitive.
-		for i := range queryClasses {
-			queryClasses[i] = strings.ToLower(queryClasses[i])
-		}
+	match, err := heroes.ParseMatch(c.Query("match"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repository, ok := scoped(c, h.repository)
+	if !ok {
+		return
+	}
 
-		if err := h.repository.GetDB().Model(&races).Distinct().Preload("RecommendedClasses").Joins("INNER JOIN race_recommended_classes rc ON (rc.race_id = id)").Joins("INNER JOIN classes c ON (rc.class_id = c.id)").Where("LOWER(c.name) IN (?)", queryClasses).Find(&races).Error; err != nil {
+	repository, ok = expanded(c, repository, &races)
+	if !ok {
+		return
+	}
+
+	if queryParamNotEmpty {
+		query := database.RecommendedClasses.WhereMatches(repository.GetDB().Preload("RecommendedClasses"), match, queryClasses)
+		if err := query.Find(&races).Error; err != nil {
 			log.Println("Error while executing getRacesByRecommendedClasses: ", err)
 			c.JSON(http.StatusInternalServerError, fmt.Sprintf("{classes: %s, message: \"Unable to process your request right now. Please check with system administrator.\"}", queryClasses))
 			return
 		}
 	}
 
-	c.IndentedJSON(http.StatusOK, races)
+	render(c, http.StatusOK, races)
 }
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/search"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchHandler implements dependency injection for Searcher, so routes don't care which search backend is in use.
+type SearchHandler struct {
+	searcher search.Searcher
+}
+
+// NewSearchHandler constructs a new handler so we don't need to expose its internal fields.
+func NewSearchHandler(s search.Searcher) SearchHandler {
+	return SearchHandler{s}
+}
+
+// Search races, classes and skills by the words of the q query parameter, best matches first.
+// Results are paginated with limit and offset.
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, "q should have the words to search for.")
+		return
+	}
+
+	limit, offset, ok := parsePage(c, defaultSearchLimit, maxSearchLimit)
+	if !ok {
+		return
+	}
+
+	results, err := h.searcher.Search(query, limit+offset)
+	if err != nil {
+		log.Printf("Some error occurred while searching for %q. Err: %s", query, err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	if offset > len(results) {
+		offset = len(results)
+	}
+
+	respond(c, http.StatusOK, results[offset:])
+}
@@ -1,11 +1,12 @@
 package controllers
 
 import (
-	"strings"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
 )
 
 // SkillHandler implements dependency injection for Repository. This controller needs no visibility to database connections.
@@ -18,24 +19,76 @@ func NewSkillHandler(r database.Repository) SkillHandler {
 	return SkillHandler{r}
 }
 
-// GetAll instances of this entity.
+// GetAll instances of this entity, optionally filtered by a ?filter= expression over SkillFilters. With ?ids= only the
+// listed ones are returned, in the same order, along with the IDs not found.
 func (h *SkillHandler) GetAll(c *gin.Context) {
-	getAll(c, h.repository, &[]heroes.Skill{})
+	getAll(c, h.repository, &[]heroes.Skill{}, SkillFilters)
 }
 
-// GetByID the entity with the provided value in path parameter.
+// GetBatch the entities with the IDs listed in the request body, for lists too long to fit ?ids=.
+func (h *SkillHandler) GetBatch(c *gin.Context) {
+	postBatch(c, h.repository, &[]heroes.Skill{}, SkillFilters)
+}
+
+// GetByID the entity with the provided value in path parameter, either its ID or slug.
 func (h *SkillHandler) GetByID(c *gin.Context) {
 	getByID(c, h.repository, &heroes.Skill{})
 }
 
-// GetByType retrieve all entities whose source matches the provided value in path parameter.
+// GetByName the entity whose name or slug matches the provided value in path parameter, ignoring case and accents.
+// Responds 404 suggesting similar names otherwise.
+func (h *SkillHandler) GetByName(c *gin.Context) {
+	getByName(c, h.repository, &heroes.Skill{})
+}
+
+// Create a new entity from the request body.
+func (h *SkillHandler) Create(c *gin.Context) {
+	create(c, h.repository, &heroes.Skill{})
+}
+
+// Update replaces the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *SkillHandler) Update(c *gin.Context) {
+	update(c, h.repository, &heroes.Skill{})
+}
+
+// Patch changes only the fields present in the request body. Requires If-Match with the current ETag.
+func (h *SkillHandler) Patch(c *gin.Context) {
+	patch(c, h.repository, &heroes.Skill{})
+}
+
+// Delete soft deletes the entity with the provided value in path parameter. Requires If-Match with the current ETag.
+func (h *SkillHandler) Delete(c *gin.Context) {
+	deleteByID(c, h.repository, &heroes.Skill{})
+}
+
+// GetHistory lists every audit entry of the entity with the provided value in path parameter, oldest first.
+func (h *SkillHandler) GetHistory(c *gin.Context) {
+	getHistory(c, h.repository, &heroes.Skill{})
+}
+
+// Restore undeletes the entity with the provided value in path parameter.
+func (h *SkillHandler) Restore(c *gin.Context) {
+	restoreByID(c, h.repository, &heroes.Skill{})
+}
+
+// GetByType retrieve all entities whose source matches the provided value in path parameter. Can be narrowed further with ?filter=.
 func (h *SkillHandler) GetByType(c *gin.Context) {
-	skillType := heroes.SkillType(strings.ToLower(c.Param("type")))
-	getByField(c, h.repository, &[]heroes.Skill{}, &heroes.Skill{Type: skillType})
+	skillType, err := heroes.ParseSkillType(c.Param("type"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list(c, h.repository, &[]heroes.Skill{}, SkillFilters, filter.Comparison{Field: "type", Operator: filter.Eq, Values: []string{string(skillType)}})
 }
 
-// GetBySource retrieve all entities whose source matches the provided value in path parameter.
+// GetBySource retrieve all entities whose source matches the provided value in path parameter. Can be narrowed further with ?filter=.
 func (h *SkillHandler) GetBySource(c *gin.Context) {
-	source := heroes.Source(strings.ToLower(c.Param("source")))
-	getByField(c, h.repository, &[]heroes.Skill{}, &heroes.Skill{Source: source})
+	source, err := heroes.ParseSource(c.Param("source"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list(c, h.repository, &[]heroes.Skill{}, SkillFilters, filter.Comparison{Field: "source", Operator: filter.Eq, Values: []string{string(source)}})
 }
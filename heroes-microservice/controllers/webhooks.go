@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/webhooks"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+// WebhookHandler manages webhook subscriptions and exposes their delivery log.
+type WebhookHandler struct {
+	repository database.Repository
+	dispatcher *webhooks.Dispatcher
+}
+
+// NewWebhookHandler constructs a new handler so we don't need to expose its internal fields.
+func NewWebhookHandler(r database.Repository, d *webhooks.Dispatcher) WebhookHandler {
+	return WebhookHandler{r, d}
+}
+
+// GetAll subscriptions. Secrets are never returned after creation.
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	var subscriptions []webhooks.Subscription
+	if err := h.repository.GetDB().Order("id ASC").Find(&subscriptions).Error; err != nil {
+		log.Println("Error while listing webhook subscriptions: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	respond(c, http.StatusOK, subscriptions)
+}
+
+// GetByID the subscription with the provided value in path parameter. Its secret is never returned after creation.
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var subscription webhooks.Subscription
+	if err := h.repository.GetDB().First(&subscription, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, "Webhook subscription not found.")
+		return
+	}
+
+	subscription.Secret = ""
+	respond(c, http.StatusOK, subscription)
+}
+
+// Create a subscription from the request body. A random secret is generated when none is provided.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var subscription webhooks.Subscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if subscription.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Println("Error while generating webhook secret: ", err)
+			c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+			return
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+
+	subscription.ID = 0
+	if err := h.repository.GetDB().Create(&subscription).Error; err != nil {
+		log.Println("Error while creating webhook subscription: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	respond(c, http.StatusCreated, subscription)
+}
+
+// Delete the subscription with the provided value in path parameter, along with its delivery log.
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	result := h.repository.GetDB().Delete(&webhooks.Subscription{}, id)
+	if result.Error != nil {
+		log.Println("Error while deleting webhook subscription: ", result.Error)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, "Webhook subscription not found.")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries lists the delivery log of the subscription with the provided value in path parameter, latest first.
+// Can be filtered by status and paginated with limit and offset.
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	h.findDeliveries(c, "subscription_id = ?", id)
+}
+
+// GetDeadLetters lists deliveries of every subscription that ran out of attempts, latest first.
+func (h *WebhookHandler) GetDeadLetters(c *gin.Context) {
+	h.findDeliveries(c, "status = ?", webhooks.Dead)
+}
+
+// Retry a dead delivery with the provided value in path parameter.
+func (h *WebhookHandler) Retry(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.dispatcher.Retry(id); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			c.JSON(http.StatusNotFound, "Dead delivery not found.")
+		} else {
+			log.Println("Error while retrying webhook delivery: ", err)
+			c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		}
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *WebhookHandler) findDeliveries(c *gin.Context, condition string, value interface{}) {
+	query := h.repository.GetDB().Where(condition, value)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	limit, offset, ok := parsePage(c, defaultDeliveryLimit, maxDeliveryLimit)
+	if !ok {
+		return
+	}
+
+	var deliveries []webhooks.Delivery
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		log.Println("Error while executing findDeliveries: ", err)
+		c.JSON(http.StatusInternalServerError, "Unable to process your request right now. Please check with system administrator.")
+		return
+	}
+
+	respond(c, http.StatusOK, deliveries)
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAppendOnly is returned when something tries to change or remove an audit entry.
+var ErrAppendOnly = errors.New("audit entries are append-only")
+
+// JSON is a raw JSON document stored in a jsonb column.
+type JSON json.RawMessage
+
+// AuditEntry records who changed an entity, when, and what it looked like before and after the change.
+// Diff holds only the fields that changed, as {"field": {"before": ..., "after": ...}}.
+type AuditEntry struct {
+	ID         uint64    `json:"id" gorm:"primary_key"`
+	Actor      string    `json:"actor" gorm:"index"`
+	Timestamp  time.Time `json:"timestamp" gorm:"index"`
+	Action     Action    `json:"action"`
+	EntityType string    `json:"entity_type" gorm:"index:idx_audit_entity"`
+	EntityID   uint64    `json:"entity_id" gorm:"index:idx_audit_entity"`
+	Before     JSON      `json:"before"`
+	After      JSON      `json:"after"`
+	Diff       JSON      `json:"diff"`
+}
+
+// BeforeUpdate keeps audit entries append-only.
+func (AuditEntry) BeforeUpdate(*gorm.DB) error {
+	return ErrAppendOnly
+}
+
+// BeforeDelete keeps audit entries append-only.
+func (AuditEntry) BeforeDelete(*gorm.DB) error {
+	return ErrAppendOnly
+}
+
+// AuditHook is a ChangeHook that appends an AuditEntry for every change, in the same transaction as the change.
+func AuditHook(tx *gorm.DB, change *Change) error {
+	entry, err := NewAuditEntry(change)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&entry).Error
+}
+
+// NewAuditEntry describes the provided change as an AuditEntry.
+func NewAuditEntry(change *Change) (AuditEntry, error) {
+	entry := AuditEntry{
+		Actor:      change.Actor,
+		Timestamp:  change.Time,
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+	}
+
+	var err error
+	if entry.Before, err = marshalJSON(change.Before); err != nil {
+		return entry, err
+	}
+
+	if entry.After, err = marshalJSON(change.After); err != nil {
+		return entry, err
+	}
+
+	entry.Diff, err = diffJSON(entry.Before, entry.After)
+	return entry, err
+}
+
+func marshalJSON(value interface{}) (JSON, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	document, err := json.Marshal(value)
+	return JSON(document), err
+}
+
+// diffJSON compares two JSON objects field by field. Either side might be empty, as on creations.
+func diffJSON(before JSON, after JSON) (JSON, error) {
+	beforeFields, afterFields := map[string]json.RawMessage{}, map[string]json.RawMessage{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(after) > 0 {
+		if err := json.Unmarshal(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	type fieldDiff struct {
+		Before json.RawMessage `json:"before"`
+		After  json.RawMessage `json:"after"`
+	}
+
+	diff := map[string]fieldDiff{}
+	for field, value := range afterFields {
+		if previous, found := beforeFields[field]; !found || !bytes.Equal(previous, value) {
+			diff[field] = fieldDiff{Before: beforeFields[field], After: value}
+		}
+	}
+
+	for field, previous := range beforeFields {
+		if _, found := afterFields[field]; !found {
+			diff[field] = fieldDiff{Before: previous}
+		}
+	}
+
+	return marshalJSON(diff)
+}
+
+// Value stores the document as text, which Postgres casts to jsonb.
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+
+	return string(j), nil
+}
+
+// Scan reads a json or jsonb column.
+func (j *JSON) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append(JSON{}, value...)
+	case string:
+		*j = JSON(value)
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", src)
+	}
+
+	return nil
+}
+
+// MarshalJSON embeds the document as is.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+
+	return j, nil
+}
+
+// UnmarshalJSON keeps a copy of the raw document.
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*j = nil
+		return nil
+	}
+
+	*j = append(JSON{}, data...)
+	return nil
+}
+
+// GormDataType maps JSON to a jsonb column.
+func (JSON) GormDataType() string {
+	return "jsonb"
+}
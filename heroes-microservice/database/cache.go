@@ -0,0 +1,253 @@
+package database
+
+import (
+	"container/list"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cacheInstances makes gorm callback names unique when more than one cache wraps the same connection.
+var cacheInstances uint64
+
+// CacheStats summarizes how effective the cache has been since it was created.
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+}
+
+// CachedRepository is a read-through cache decorator for any Repository.
+// Successful lookups are memoized for at most ttl and the least recently used entries are evicted above maxEntries.
+// Any create, update or delete issued through the wrapped gorm.DB clears the whole cache, since associations make
+// per-entry invalidation unreliable. Cached values are shallow copies, so callers must treat results as read-only.
+// Unscoped and paged lookups are never cached, while expanded ones are cached apart from the others.
+type CachedRepository struct {
+	Repository
+	*lruCache
+	expansion []string
+}
+
+// lruCache holds the memoized results, shared by every CachedRepository derived through WithActor.
+type lruCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mutex     sync.Mutex
+	entries   map[string]*list.Element
+	lru       *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+	// generation counts invalidations, so lookups that raced with one don't store what they read before it.
+	generation uint64
+}
+
+// cacheEntry is a single memoized result kept in the LRU list.
+type cacheEntry struct {
+	key       string
+	value     reflect.Value
+	expiresAt time.Time
+}
+
+// NewCachedRepository wraps the provided Repository with a cache bounded by ttl and maxEntries (zero means unbounded).
+// The ttl must be positive, otherwise every entry expires as soon as it is stored.
+func NewCachedRepository(r Repository, ttl time.Duration, maxEntries int) *CachedRepository {
+	cache := &lruCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	cache.registerInvalidation(r.GetDB())
+
+	return &CachedRepository{Repository: r, lruCache: cache}
+}
+
+// WithActor keeps the cache in front of a Repository whose writes are attributed to the provided actor.
+func (r *CachedRepository) WithActor(actor string) Repository {
+	return &CachedRepository{Repository: r.Repository.WithActor(actor), lruCache: r.lruCache, expansion: r.expansion}
+}
+
+// Expand keeps the cache in front of a Repository preloading the provided association paths.
+func (r *CachedRepository) Expand(associations ...string) Repository {
+	expansion := append([]string{}, associations...)
+	sort.Strings(expansion)
+	return &CachedRepository{Repository: r.Repository.Expand(associations...), lruCache: r.lruCache, expansion: expansion}
+}
+
+// FindAll returns the memoized records when available, otherwise delegates to the wrapped Repository.
+func (r *CachedRepository) FindAll(dest interface{}) bool {
+	return r.readThrough(r.key("all", dest), dest, func() bool { return r.Repository.FindAll(dest) })
+}
+
+// FindByID returns the memoized record when available, otherwise delegates to the wrapped Repository.
+func (r *CachedRepository) FindByID(dest interface{}, id uint64) bool {
+	key := r.key("id", dest, strconv.FormatUint(id, 10))
+	return r.readThrough(key, dest, func() bool { return r.Repository.FindByID(dest, id) })
+}
+
+// FindByField returns the memoized records when available, otherwise delegates to the wrapped Repository.
+// Queries are told apart by the SQL they run, whether they are maps, structs or clauses.
+func (r *CachedRepository) FindByField(dest interface{}, query interface{}) bool {
+	statement := r.Repository.GetDB().ToSQL(func(tx *gorm.DB) *gorm.DB { return tx.Find(newOf(dest), query) })
+	return r.readThrough(r.key("field", dest, statement), dest, func() bool { return r.Repository.FindByField(dest, query) })
+}
+
+// key identifies a lookup by its kind, the type it fills, the associations it preloads and its arguments, such as
+// "id|heroes.Race|RecommendedClasses|2".
+func (r *CachedRepository) key(lookup string, dest interface{}, args ...string) string {
+	parts := append([]string{lookup, reflect.TypeOf(dest).Elem().String(), strings.Join(r.expansion, ",")}, args...)
+	return strings.Join(parts, "|")
+}
+
+// Create inserts the value through the wrapped Repository and drops every memoized result once committed.
+func (r *CachedRepository) Create(value interface{}) error {
+	defer r.Invalidate()
+	return r.Repository.Create(value)
+}
+
+// Update overwrites the record through the wrapped Repository and drops every memoized result once committed.
+func (r *CachedRepository) Update(model interface{}, id uint64, version uint64) error {
+	defer r.Invalidate()
+	return r.Repository.Update(model, id, version)
+}
+
+// Delete soft deletes the record through the wrapped Repository and drops every memoized result once committed.
+func (r *CachedRepository) Delete(model interface{}, id uint64, version uint64) error {
+	defer r.Invalidate()
+	return r.Repository.Delete(model, id, version)
+}
+
+// Restore undeletes the record through the wrapped Repository and drops every memoized result once committed.
+func (r *CachedRepository) Restore(model interface{}, id uint64) error {
+	defer r.Invalidate()
+	return r.Repository.Restore(model, id)
+}
+
+// Transaction runs work through the wrapped Repository, so lookups inside it see its writes, and drops every memoized
+// result once committed.
+func (r *CachedRepository) Transaction(work func(Repository) error) error {
+	defer r.Invalidate()
+	return r.Repository.Transaction(work)
+}
+
+// Stats reports hit, miss and eviction counters along with the current number of entries.
+func (r *lruCache) Stats() CacheStats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return CacheStats{Hits: r.hits, Misses: r.misses, Evictions: r.evictions, Entries: r.lru.Len()}
+}
+
+// Invalidate drops every memoized result, along with the ones still being read.
+func (r *lruCache) Invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = make(map[string]*list.Element)
+	r.lru.Init()
+	r.generation++
+}
+
+// readThrough loads the memoized result of key, or finds and stores it. Results found while the cache was invalidated
+// may predate the change, so they are not stored.
+func (r *lruCache) readThrough(key string, dest interface{}, find func() bool) bool {
+	found, generation := r.load(key, dest)
+	if found {
+		return true
+	}
+
+	if !find() {
+		return false
+	}
+
+	r.store(key, dest, generation)
+	return true
+}
+
+// load copies the memoized result of key into dest, telling whether it did along with the current generation.
+func (r *lruCache) load(key string, dest interface{}) (bool, uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	element, found := r.entries[key]
+	if !found {
+		r.misses++
+		return false, r.generation
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		r.remove(element)
+		r.misses++
+		return false, r.generation
+	}
+
+	reflect.ValueOf(dest).Elem().Set(entry.value)
+	r.lru.MoveToFront(element)
+	r.hits++
+	return true, r.generation
+}
+
+// store memoizes dest under key, unless the cache was invalidated since generation.
+func (r *lruCache) store(key string, dest interface{}, generation uint64) {
+	value := reflect.ValueOf(dest).Elem()
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if generation != r.generation {
+		return
+	}
+
+	entry := &cacheEntry{key: key, value: copied, expiresAt: time.Now().Add(r.ttl)}
+	if element, found := r.entries[key]; found {
+		element.Value = entry
+		r.lru.MoveToFront(element)
+		return
+	}
+
+	r.entries[key] = r.lru.PushFront(entry)
+	for r.maxEntries > 0 && r.lru.Len() > r.maxEntries {
+		r.remove(r.lru.Back())
+		r.evictions++
+	}
+}
+
+func (r *lruCache) remove(element *list.Element) {
+	r.lru.Remove(element)
+	delete(r.entries, element.Value.(*cacheEntry).key)
+}
+
+// registerInvalidation hooks into gorm write callbacks so writes made anywhere with this connection clear the cache.
+func (r *lruCache) registerInvalidation(db *gorm.DB) {
+	if db == nil {
+		return
+	}
+
+	name := fmt.Sprintf("cache:invalidate:%d", atomic.AddUint64(&cacheInstances, 1))
+	invalidate := func(*gorm.DB) { r.Invalidate() }
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().After("gorm:create").Register(name, invalidate),
+		callbacks.Update().After("gorm:update").Register(name, invalidate),
+		callbacks.Delete().After("gorm:delete").Register(name, invalidate),
+	} {
+		if err != nil {
+			log.Println("Error while registering cache invalidation: ", err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Action is the kind of write that produced a Change.
+type Action string
+
+const (
+	// Created entities did not exist before the change.
+	Created Action = "create"
+	// Updated entities had their columns overwritten.
+	Updated Action = "update"
+	// Deleted entities were soft deleted (or permanently removed through an Unscoped Repository).
+	Deleted Action = "delete"
+	// Restored entities had their soft deletion undone.
+	Restored Action = "restore"
+)
+
+// Change describes a single write made through the Repository.
+// Before and After are snapshots of the entity columns, without associations. Before is nil for creations.
+type Change struct {
+	Action     Action
+	EntityType string
+	EntityID   uint64
+	Actor      string
+	Time       time.Time
+	Before     interface{}
+	After      interface{}
+}
+
+// ChangeHook runs inside the same transaction as the write it describes. Returning an error rolls the write back.
+type ChangeHook func(tx *gorm.DB, change *Change) error
+
+// ChangeListener runs after the transaction of the write it describes was committed.
+type ChangeListener func(change Change)
+
+// EntityType resolves the name used to identify the provided model in changes, which is its table name.
+func EntityType(db *gorm.DB, model interface{}) string {
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(model); err != nil {
+		return ""
+	}
+
+	return statement.Schema.Table
+}
+
+// transaction runs the write along with every registered ChangeHook atomically, then notifies every ChangeListener.
+// Inside Transaction, the change is left pending until committed instead.
+// Snapshots are only loaded when there are hooks or listeners interested in them.
+func (r *repository) transaction(action Action, model interface{}, id uint64, write func(tx *gorm.DB) error) error {
+	run := r.db.Transaction
+	if r.pending != nil {
+		// Writes made inside Transaction already run in its own, which is rolled back as a whole on failure.
+		run = func(write func(tx *gorm.DB) error, _ ...*sql.TxOptions) error { return write(r.db) }
+	}
+
+	if len(*r.hooks) == 0 && len(*r.listeners) == 0 {
+		return run(write)
+	}
+
+	change := &Change{Action: action, EntityID: id, Actor: r.actor, Time: time.Now()}
+	err := run(func(tx *gorm.DB) error {
+		change.EntityType = EntityType(tx, model)
+		if action != Created {
+			change.Before = snapshot(tx, model, id)
+		}
+
+		if err := write(tx); err != nil {
+			return err
+		}
+
+		if action == Created {
+			change.EntityID = primaryKey(model)
+			change.After = model
+		} else {
+			change.After = snapshot(tx, model, id)
+		}
+
+		for _, hook := range *r.hooks {
+			if err := hook(tx, change); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if r.pending != nil {
+		*r.pending = append(*r.pending, *change)
+		return nil
+	}
+
+	for _, listener := range *r.listeners {
+		listener(*change)
+	}
+
+	return nil
+}
+
+// snapshot loads the current columns of a record, including soft deleted ones. Returns nil when there is no such record.
+func snapshot(tx *gorm.DB, model interface{}, id uint64) interface{} {
+	current := newOf(model)
+	if err := tx.Unscoped().Limit(1).Find(current, id).Error; err != nil || primaryKey(current) != id {
+		return nil
+	}
+
+	return current
+}
+
+func primaryKey(model interface{}) uint64 {
+	field := reflect.Indirect(reflect.ValueOf(model)).FieldByName("ID")
+	if !field.IsValid() || field.Kind() != reflect.Uint64 {
+		return 0
+	}
+
+	return field.Uint()
+}
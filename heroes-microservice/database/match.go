@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"gorm.io/gorm"
+)
+
+// Association describes how an entity reaches the values it is filtered by, through a many to many join table.
+type Association struct {
+	Table       string
+	JoinTable   string
+	OwnerKey    string
+	TargetKey   string
+	TargetTable string
+	Column      string
+}
+
+var (
+	// RecommendedClasses filters races by the names of the classes they recommend.
+	RecommendedClasses = Association{"races", "race_recommended_classes", "race_id", "class_id", "classes", "name"}
+	// ClassProficiencies filters classes by their proficiencies.
+	ClassProficiencies = Association{"classes", "class_proficiencies", "class_id", "proficiency_id", "proficiencies", "name"}
+)
+
+// WhereMatches filters db with the SQL counterpart of heroes.Match.Matches, ignoring case and soft deleted targets.
+// Matching all groups owners by the distinct wanted values they have, keeping those having as many as were asked for.
+func (a Association) WhereMatches(db *gorm.DB, m heroes.Match, wanted []string) *gorm.DB {
+	wanted = heroes.Normalize(wanted)
+	owners := fmt.Sprintf("SELECT j.%s FROM %s j INNER JOIN %s t ON (t.id = j.%s) WHERE t.deleted_at IS NULL AND LOWER(t.%s) IN ?",
+		a.OwnerKey, a.JoinTable, a.TargetTable, a.TargetKey, a.Column)
+
+	switch m {
+	case heroes.MatchAll:
+		having := fmt.Sprintf(" GROUP BY j.%s HAVING COUNT(DISTINCT LOWER(t.%s)) = ?", a.OwnerKey, a.Column)
+		return db.Where(a.Table+".id IN ("+owners+having+")", wanted, len(wanted))
+	case heroes.MatchNone:
+		return db.Where(a.Table+".id NOT IN ("+owners+")", wanted)
+	default:
+		return db.Where(a.Table+".id IN ("+owners+")", wanted)
+	}
+}
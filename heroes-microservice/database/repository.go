@@ -1,29 +1,89 @@
 package database
 
 import (
+	"errors"
 	"log"
+	"reflect"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// ErrNotFound is returned by write operations when the targeted record does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrVersionMismatch is returned by write operations when the record was changed since the provided version was read.
+var ErrVersionMismatch = errors.New("record version mismatch")
+
+// versioned entities carry an optimistic concurrency version, such as the ones embedding heroes.Revision.
+type versioned interface {
+	GetVersion() uint64
+	SetVersion(version uint64)
+}
+
 // Repository implements dependency injection for database connection.
-type Repository struct {
-	db *gorm.DB
+type Repository interface {
+	// GetDB gives direct access to gorm.DB capabilities.
+	GetDB() *gorm.DB
+	// FindAll searches all records of the desired interface.
+	FindAll(dest interface{}) bool
+	// FindByID searches desired interface using provided primary key.
+	FindByID(dest interface{}, id uint64) bool
+	// FindByField finds the desired interface applying the provided query parameter.
+	FindByField(dest interface{}, query interface{}) bool
+	// Unscoped gives a Repository that also sees soft deleted records.
+	Unscoped() Repository
+	// Expand gives a Repository whose lookups preload exactly the provided association paths, such as
+	// "RecommendedClasses.Proficiencies", instead of every direct association for FindByID and none otherwise.
+	Expand(associations ...string) Repository
+	// Page gives a Repository whose FindAll and FindByField return at most limit records, ordered by primary key,
+	// after skipping offset. A negative limit returns every record after offset.
+	Page(limit int, offset int) Repository
+	// WithActor gives a Repository whose writes are attributed to the provided actor.
+	WithActor(actor string) Repository
+	// Transaction runs work with a Repository whose writes are all committed together, or rolled back when work fails.
+	// Listeners only learn about the changes once committed.
+	Transaction(work func(Repository) error) error
+	// OnChange registers a hook to run inside the transaction of every write.
+	OnChange(hook ChangeHook)
+	// OnCommit registers a listener to run after every committed write.
+	OnCommit(listener ChangeListener)
+	// Create inserts the provided value, deriving its slug from its name when none is provided. Associations are not written.
+	Create(value interface{}) error
+	// Update overwrites the record with the given primary key, as long as it is still at the provided version.
+	// Slugs are kept when none is provided. Associations are not written.
+	Update(model interface{}, id uint64, version uint64) error
+	// Delete soft deletes the record of the provided model with the given primary key, as long as it is still at the
+	// provided version. Through an Unscoped Repository the record is removed permanently instead.
+	Delete(model interface{}, id uint64, version uint64) error
+	// Restore brings back a soft deleted record of the provided model with the given primary key.
+	Restore(model interface{}, id uint64) error
+}
+
+// repository is the gorm backed Repository implementation.
+// Hooks and listeners are shared with every Repository derived from it through Unscoped or WithActor.
+// Inside Transaction, changes wait in pending until committed.
+type repository struct {
+	db        *gorm.DB
+	actor     string
+	expanded  bool
+	hooks     *[]ChangeHook
+	listeners *[]ChangeListener
+	pending   *[]Change
 }
 
 // NewRepository constructs a new Repository so we don't need to expose Repository's internal fields.
 func NewRepository(db *gorm.DB) Repository {
-	return Repository{db}
+	return &repository{db: db, hooks: &[]ChangeHook{}, listeners: &[]ChangeListener{}}
 }
 
 // GetDB gives direct access to gorm.DB capabilities.
-func (r *Repository) GetDB() *gorm.DB {
+func (r *repository) GetDB() *gorm.DB {
 	return r.db
 }
 
 // FindAll is an abstraction of gorm.Find. Searches all records of the desired interface.
-func (r *Repository) FindAll(dest interface{}) bool {
+func (r *repository) FindAll(dest interface{}) bool {
 	if err := r.db.Find(dest).Error; err != nil {
 		log.Println("Error while executing getAll: ", err)
 		return false
@@ -33,8 +93,13 @@ func (r *Repository) FindAll(dest interface{}) bool {
 }
 
 // FindByID is an abstraction of gorm.Find using primary key. Searches desired interface using provided primary key
-func (r *Repository) FindByID(dest interface{}, id uint64) bool {
-	if err := r.db.Preload(clause.Associations).First(dest, id).Error; err != nil {
+func (r *repository) FindByID(dest interface{}, id uint64) bool {
+	db := r.db
+	if !r.expanded {
+		db = db.Preload(clause.Associations)
+	}
+
+	if err := db.First(dest, id).Error; err != nil {
 		log.Println("Error while executing getByID: ", err)
 
 		return false
@@ -44,7 +109,7 @@ func (r *Repository) FindByID(dest interface{}, id uint64) bool {
 }
 
 // FindByField is an abstraction of gorm.Find. Finds the desired interface applying the provided query parameter.
-func (r *Repository) FindByField(dest interface{}, query interface{}) bool {
+func (r *repository) FindByField(dest interface{}, query interface{}) bool {
 	if err := r.db.Find(dest, query).Error; err != nil {
 		log.Println("Error while executing findByField: ", err)
 		return false
@@ -52,3 +117,163 @@ func (r *Repository) FindByField(dest interface{}, query interface{}) bool {
 
 	return true
 }
+
+// Unscoped disables gorm's soft delete filter for every query made through the returned Repository.
+func (r *repository) Unscoped() Repository {
+	return &repository{db: r.db.Unscoped(), actor: r.actor, expanded: r.expanded, hooks: r.hooks, listeners: r.listeners, pending: r.pending}
+}
+
+// Expand preloads the provided association paths on every query made through the returned Repository, including the
+// ones made through GetDB.
+func (r *repository) Expand(associations ...string) Repository {
+	db := r.db
+	for _, association := range associations {
+		db = db.Preload(association)
+	}
+
+	// A new session makes the preloads safe to reuse on every query.
+	return &repository{db: db.Session(&gorm.Session{}), actor: r.actor, expanded: true, hooks: r.hooks, listeners: r.listeners, pending: r.pending}
+}
+
+// Page limits FindAll and FindByField made through the returned Repository to a page of records ordered by primary key,
+// so consecutive pages neither repeat nor skip records.
+func (r *repository) Page(limit int, offset int) Repository {
+	db := r.db.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey}}).Limit(limit).Offset(offset)
+	return &repository{db: db, actor: r.actor, expanded: r.expanded, hooks: r.hooks, listeners: r.listeners, pending: r.pending}
+}
+
+// WithActor attributes every change made through the returned Repository to the provided actor.
+func (r *repository) WithActor(actor string) Repository {
+	return &repository{db: r.db, actor: actor, expanded: r.expanded, hooks: r.hooks, listeners: r.listeners, pending: r.pending}
+}
+
+// Transaction is an abstraction of gorm.Transaction. Every write made through the Repository given to work joins the
+// same transaction, and listeners are notified of them in order once it is committed.
+func (r *repository) Transaction(work func(Repository) error) error {
+	var pending []Change
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return work(&repository{db: tx, actor: r.actor, expanded: r.expanded, hooks: r.hooks, listeners: r.listeners, pending: &pending})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, change := range pending {
+		for _, listener := range *r.listeners {
+			listener(change)
+		}
+	}
+
+	return nil
+}
+
+// OnChange registers a hook to run inside the transaction of every write. Hooks should be registered during startup.
+func (r *repository) OnChange(hook ChangeHook) {
+	*r.hooks = append(*r.hooks, hook)
+}
+
+// OnCommit registers a listener to run after every committed write. Listeners should be registered during startup.
+func (r *repository) OnCommit(listener ChangeListener) {
+	*r.listeners = append(*r.listeners, listener)
+}
+
+// Create is an abstraction of gorm.Create. Every new record starts at version 1, with a unique slug when slugged.
+func (r *repository) Create(value interface{}) error {
+	if v, ok := value.(versioned); ok {
+		v.SetVersion(1)
+	}
+
+	return r.transaction(Created, value, 0, func(tx *gorm.DB) error {
+		if err := assignSlug(tx, value, 0); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(value).Error; err != nil {
+			log.Println("Error while executing create: ", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Update is an abstraction of gorm.Updates. The version check and increment happen in the same UPDATE statement.
+func (r *repository) Update(model interface{}, id uint64, version uint64) error {
+	if v, ok := model.(versioned); ok {
+		v.SetVersion(version + 1)
+	}
+
+	omit := []string{"id", "created_at", "deleted_at", clause.Associations}
+	s, ok := model.(slugged)
+	if ok && s.GetSlug() == "" {
+		// Slugs are stable, so renames keep them unless a new one is sent.
+		omit = append(omit, "slug")
+	}
+
+	return r.transaction(Updated, model, id, func(tx *gorm.DB) error {
+		if ok && s.GetSlug() != "" {
+			if err := assignSlug(tx, model, id); err != nil {
+				return err
+			}
+		}
+
+		// Model is a zero value, so an ID sent in the body never overrides the one provided.
+		result := tx.Model(newOf(model)).Where("id = ? AND version = ?", id, version).Select("*").Omit(omit...).Updates(model)
+		return checkWrite(tx, result, model, id, "update")
+	})
+}
+
+// Delete is an abstraction of gorm.Delete. Marks the record as deleted instead of removing it.
+func (r *repository) Delete(model interface{}, id uint64, version uint64) error {
+	return r.transaction(Deleted, model, id, func(tx *gorm.DB) error {
+		result := tx.Where("version = ?", version).Delete(model, id)
+		return checkWrite(tx, result, model, id, "delete")
+	})
+}
+
+// Restore clears the deletion mark of a soft deleted record.
+func (r *repository) Restore(model interface{}, id uint64) error {
+	return r.transaction(Restored, model, id, func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			log.Println("Error while executing restore: ", result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// checkWrite tells apart missing records from stale versions when a versioned write affected no rows.
+func checkWrite(tx *gorm.DB, result *gorm.DB, model interface{}, id uint64, operation string) error {
+	if result.Error != nil {
+		log.Printf("Error while executing %s: %s", operation, result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		log.Printf("Error while executing %s: %s", operation, err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrNotFound
+	}
+
+	return ErrVersionMismatch
+}
+
+// newOf allocates a new zero value of the same type the provided pointer points to.
+func newOf(model interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(model).Elem()).Interface()
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"gorm.io/gorm"
+)
+
+// slugged entities have a URL friendly alternate key to their IDs, such as the ones embedding heroes.Slugged.
+type slugged interface {
+	GetName() string
+	GetSlug() string
+	SetSlug(slug string)
+}
+
+// assignSlug normalizes the slug of slugged entities, deriving it from their names when none was provided.
+// Slugs taken by other records, even deleted ones, are suffixed with a number: "elf-2". Numeric slugs would be read
+// as IDs, so they are prefixed with the entity type instead: "skill-300".
+func assignSlug(tx *gorm.DB, model interface{}, id uint64) error {
+	s, ok := model.(slugged)
+	if !ok {
+		return nil
+	}
+
+	base := heroes.Slugify(s.GetSlug())
+	if base == "" {
+		base = heroes.Slugify(s.GetName())
+	}
+
+	if base == "" {
+		return nil
+	}
+
+	if _, err := strconv.ParseUint(base, 10, 64); err == nil {
+		base = strings.ToLower(reflect.TypeOf(model).Elem().Name()) + "-" + base
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		var count int64
+		if err := tx.Unscoped().Model(newOf(model)).Where("slug = ? AND id <> ?", slug, id).Count(&count).Error; err != nil {
+			log.Println("Error while executing assignSlug: ", err)
+			return err
+		}
+
+		if count == 0 {
+			s.SetSlug(slug)
+			return nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// BackfillSlugs assigns slugs to every record of the slugged model stored without one, such as *heroes.Race.
+// Versions are left untouched, as these records didn't really change.
+func BackfillSlugs(db *gorm.DB, model interface{}) error {
+	records := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Order("id").Find(records.Interface()).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < records.Elem().Len(); i++ {
+			record := records.Elem().Index(i).Addr().Interface()
+			id := primaryKey(record)
+			if err := assignSlug(tx, record, id); err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Model(record).UpdateColumn("slug", record.(slugged).GetSlug()).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
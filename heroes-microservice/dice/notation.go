@@ -0,0 +1,237 @@
+// Package dice parses and rolls dice notation, such as
+//
+//	2d6+3
+//
+// which reads as "roll two six sided dice and add three". Terms are added or subtracted from each other, and are
+// either constants or dice written as [count]d<sides>, the count being 1 when left out. Dice can be followed by:
+//
+//   - ! to explode: every die rolling its highest side adds another die, such as 3d6!
+//   - khN or klN to keep only the N highest or lowest dice, such as 4d6kh3
+//
+// Exploding happens before keeping, so extra dice can be kept or dropped as any other. Spaces are allowed between
+// numbers and symbols, and letters are case insensitive.
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxLength caps how long a notation can be, as a cheap guard against abusive rolls.
+	MaxLength = 100
+	// MaxDice caps how many dice a single term rolls, before exploding.
+	MaxDice = 100
+	// MaxSides caps how many sides dice can have.
+	MaxSides = 1000
+	// MaxExplosions caps how many extra dice a single term can roll when exploding, as dice with few sides could
+	// explode for a long time.
+	MaxExplosions = 100
+)
+
+// Expression is a parsed dice notation, which can be rolled any number of times.
+type Expression struct {
+	Terms []Term
+}
+
+// Term is either a group of dice or a constant, added to or subtracted from the total.
+type Term struct {
+	Negative bool
+	// Count of dice to roll, or the value of constants.
+	Count int
+	// Sides of each die, zero for constants.
+	Sides   int
+	Explode bool
+	// Keep only this many dice, every one when zero.
+	Keep       int
+	KeepLowest bool
+}
+
+// SyntaxError tells where a notation stopped making sense.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
+
+// Parse reads a dice notation, such as 4d6kh3+2.
+func Parse(notation string) (Expression, error) {
+	if len(notation) > MaxLength {
+		return Expression{}, &SyntaxError{MaxLength, fmt.Sprintf("notations are limited to %d characters", MaxLength)}
+	}
+
+	p := &parser{runes: []rune(strings.ToLower(notation))}
+	var e Expression
+	for p.skip(); p.i < len(p.runes); p.skip() {
+		negative := false
+		switch r := p.runes[p.i]; {
+		case r == '+' || r == '-':
+			negative = r == '-'
+			p.i++
+		case len(e.Terms) > 0:
+			return Expression{}, p.errorf("expected + or - instead of %q", r)
+		}
+
+		term, err := p.term()
+		if err != nil {
+			return Expression{}, err
+		}
+		term.Negative = negative
+		e.Terms = append(e.Terms, term)
+	}
+
+	if len(e.Terms) == 0 {
+		return Expression{}, &SyntaxError{1, "expected dice, such as 2d6+3"}
+	}
+
+	return e, nil
+}
+
+// String writes the expression in its canonical notation, such as 1d20+2.
+func (e Expression) String() string {
+	var b strings.Builder
+	for i, term := range e.Terms {
+		if term.Negative {
+			b.WriteByte('-')
+		} else if i > 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(term.String())
+	}
+
+	return b.String()
+}
+
+// String writes the term in its canonical notation without its sign, such as 4d6kh3.
+func (t Term) String() string {
+	if t.Sides == 0 {
+		return strconv.Itoa(t.Count)
+	}
+
+	notation := fmt.Sprintf("%dd%d", t.Count, t.Sides)
+	if t.Explode {
+		notation += "!"
+	}
+
+	if t.Keep > 0 {
+		keep := "kh"
+		if t.KeepLowest {
+			keep = "kl"
+		}
+		notation += fmt.Sprintf("%s%d", keep, t.Keep)
+	}
+
+	return notation
+}
+
+// parser reads runes one token at a time. Positions in errors are 1-based, so they read naturally.
+type parser struct {
+	runes []rune
+	i     int
+}
+
+func (p *parser) term() (Term, error) {
+	p.skip()
+	start := p.i
+	count, ok, err := p.number()
+	if err != nil {
+		return Term{}, err
+	}
+
+	if !p.accept('d') {
+		if !ok {
+			return Term{}, p.errorf("expected a number or dice")
+		}
+		return Term{Count: count}, nil
+	}
+
+	if !ok {
+		count = 1
+	}
+
+	if count < 1 || count > MaxDice {
+		return Term{}, &SyntaxError{start + 1, fmt.Sprintf("should roll from 1 to %d dice", MaxDice)}
+	}
+
+	sides, ok, err := p.number()
+	if err != nil {
+		return Term{}, err
+	}
+
+	if !ok {
+		return Term{}, p.errorf("expected the number of sides")
+	}
+
+	if sides < 2 || sides > MaxSides {
+		return Term{}, &SyntaxError{start + 1, fmt.Sprintf("dice should have from 2 to %d sides", MaxSides)}
+	}
+
+	t := Term{Count: count, Sides: sides, Explode: p.accept('!')}
+	if p.accept('k') {
+		switch {
+		case p.accept('h'):
+		case p.accept('l'):
+			t.KeepLowest = true
+		default:
+			return Term{}, p.errorf("expected kh or kl to keep the highest or lowest dice")
+		}
+
+		p.skip()
+		keepAt := p.i
+		if t.Keep, ok, err = p.number(); err != nil {
+			return Term{}, err
+		}
+
+		if !ok || t.Keep < 1 || t.Keep > count {
+			return Term{}, &SyntaxError{keepAt + 1, fmt.Sprintf("should keep from 1 to %d dice", count)}
+		}
+	}
+
+	return t, nil
+}
+
+// number reads the digits at the current position, telling whether there were any.
+func (p *parser) number() (int, bool, error) {
+	p.skip()
+	start := p.i
+	for p.i < len(p.runes) && p.runes[p.i] >= '0' && p.runes[p.i] <= '9' {
+		p.i++
+	}
+
+	if start == p.i {
+		return 0, false, nil
+	}
+
+	number, err := strconv.Atoi(string(p.runes[start:p.i]))
+	if err != nil || number > MaxSides*MaxDice {
+		return 0, false, &SyntaxError{start + 1, "number is too large"}
+	}
+
+	return number, true, nil
+}
+
+func (p *parser) accept(r rune) bool {
+	p.skip()
+	if p.i < len(p.runes) && p.runes[p.i] == r {
+		p.i++
+		return true
+	}
+
+	return false
+}
+
+// skip moves past spaces, which are allowed between any tokens.
+func (p *parser) skip() {
+	for p.i < len(p.runes) && unicode.IsSpace(p.runes[p.i]) {
+		p.i++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{p.i + 1, fmt.Sprintf(format, args...)}
+}
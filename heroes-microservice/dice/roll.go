@@ -0,0 +1,110 @@
+package dice
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Result of rolling an expression, along with every die rolled.
+type Result struct {
+	Notation string       `json:"notation"`
+	Terms    []TermResult `json:"terms"`
+	Total    int          `json:"total"`
+}
+
+// TermResult is the outcome of a single term. Constants have no dice.
+type TermResult struct {
+	Notation string `json:"notation"`
+	Dice     []Die  `json:"dice,omitempty"`
+	// Total of the kept dice or the constant, negative when subtracted.
+	Total int `json:"total"`
+}
+
+// Die is a single die rolled, in the order it was rolled.
+type Die struct {
+	Sides int `json:"sides"`
+	Value int `json:"value"`
+	// Exploded dice rolled their highest side, adding another die.
+	Exploded bool `json:"exploded,omitempty"`
+	// Dropped dice were left out of the total by kh or kl.
+	Dropped bool `json:"dropped,omitempty"`
+}
+
+// Roller rolls dice from a seeded source, so rolling the same expressions from the same seed gives the same results.
+// It is not safe for concurrent use.
+type Roller struct {
+	rng *rand.Rand
+}
+
+// NewRoller constructs a Roller starting from the seed.
+func NewRoller(seed int64) *Roller {
+	return &Roller{rand.New(rand.NewSource(seed))}
+}
+
+// Roll rolls every term of the expression and adds them up.
+func (r *Roller) Roll(e Expression) Result {
+	result := Result{Notation: e.String(), Terms: make([]TermResult, len(e.Terms))}
+	for i, term := range e.Terms {
+		result.Terms[i] = r.term(term)
+		result.Total += result.Terms[i].Total
+	}
+
+	return result
+}
+
+// Die rolls a single die with the provided sides.
+func (r *Roller) Die(sides int) int {
+	return r.rng.Intn(sides) + 1
+}
+
+func (r *Roller) term(t Term) TermResult {
+	result := TermResult{Notation: t.String()}
+	if t.Sides == 0 {
+		result.Total = t.Count
+	} else {
+		for rolls, explosions := t.Count, 0; rolls > 0; rolls-- {
+			die := Die{Sides: t.Sides, Value: r.Die(t.Sides)}
+			if t.Explode && die.Value == t.Sides && explosions < MaxExplosions {
+				die.Exploded = true
+				explosions++
+				rolls++
+			}
+			result.Dice = append(result.Dice, die)
+		}
+
+		if t.Keep > 0 {
+			keep(result.Dice, t.Keep, t.KeepLowest)
+		}
+
+		for _, die := range result.Dice {
+			if !die.Dropped {
+				result.Total += die.Value
+			}
+		}
+	}
+
+	if t.Negative {
+		result.Total = -result.Total
+	}
+
+	return result
+}
+
+// keep drops every die but the n highest or lowest ones. Ties are broken by keeping the ones rolled first.
+func keep(dice []Die, n int, lowest bool) {
+	order := make([]int, len(dice))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if lowest {
+			return dice[order[i]].Value < dice[order[j]].Value
+		}
+		return dice[order[i]].Value > dice[order[j]].Value
+	})
+
+	for _, i := range order[n:] {
+		dice[i].Dropped = true
+	}
+}
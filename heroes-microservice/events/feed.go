@@ -0,0 +1,196 @@
+package events
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"gorm.io/gorm"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind before being disconnected.
+const subscriberBuffer = 64
+
+// maxPoll caps how many events a single poll delivers. The remaining ones are delivered by the next poll.
+const maxPoll = 1000
+
+// MaxReplay caps how many events Subscribe replays when resuming. Subscribers further behind catch up with Since first.
+const MaxReplay = 1000
+
+// ErrTooFarBehind is returned by Subscribe when resuming would replay more than MaxReplay events.
+var ErrTooFarBehind = errors.New("too many events to replay")
+
+// Event is a persisted notification of a change. IDs form the sequence clients resume from.
+type Event struct {
+	ID         uint64          `json:"id" gorm:"primary_key"`
+	Action     database.Action `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint64          `json:"entity_id"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+// Hook is a ChangeHook persisting an Event for every change, in the same transaction as the change.
+func Hook(tx *gorm.DB, change *database.Change) error {
+	return tx.Create(&Event{
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Timestamp:  change.Time,
+	}).Error
+}
+
+// Feed delivers persisted events to live subscribers in sequence order.
+// It polls for new events whenever a local write is committed (see Notify) and periodically, to catch up with
+// writes made by other instances sharing the same database.
+type Feed struct {
+	db    *gorm.DB
+	nudge chan struct{}
+
+	// CommitLag is how long missing IDs are waited for before the events after them are delivered. IDs are assigned
+	// when events are inserted rather than committed, so lower IDs may still show up after higher ones, unless their
+	// transaction was rolled back. Events committed even later than that are never delivered live.
+	CommitLag time.Duration
+
+	mutex       sync.Mutex
+	last        uint64
+	gap         uint64 // First missing ID being waited for.
+	gapSince    time.Time
+	subscribers map[chan Event]struct{}
+}
+
+// NewFeed constructs a Feed starting after the latest persisted event, waiting up to 5s for missing IDs.
+func NewFeed(db *gorm.DB) (*Feed, error) {
+	feed := &Feed{db: db, nudge: make(chan struct{}, 1), CommitLag: 5 * time.Second, subscribers: make(map[chan Event]struct{})}
+	if err := db.Model(&Event{}).Select("COALESCE(MAX(id), 0)").Scan(&feed.last).Error; err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// Run polls for new events until stop is closed.
+func (f *Feed) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-f.nudge:
+		}
+
+		f.Poll()
+	}
+}
+
+// Notify is a ChangeListener waking the feed up as soon as a local write is committed.
+func (f *Feed) Notify(database.Change) {
+	select {
+	case f.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// Poll delivers every event persisted since the last poll, stopping short of missing IDs until CommitLag has passed.
+func (f *Feed) Poll() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	events, err := f.find(f.last, 0, maxPoll)
+	if err != nil {
+		log.Println("Error while polling events: ", err)
+		return
+	}
+
+	for _, event := range events {
+		if event.ID > f.last+1 && !f.skipGap(time.Now()) {
+			// The next polls deliver these events again, once the missing ones are committed or given up on.
+			break
+		}
+
+		f.last = event.ID
+		for subscriber := range f.subscribers {
+			select {
+			case subscriber <- event:
+			default:
+				// Slow subscribers are dropped, they can resume from their last event once they reconnect.
+				f.remove(subscriber)
+			}
+		}
+	}
+}
+
+// skipGap tells whether the IDs missing right after the last delivered event were waited for long enough.
+func (f *Feed) skipGap(now time.Time) bool {
+	if f.gap != f.last+1 {
+		f.gap, f.gapSince = f.last+1, now
+	}
+
+	return now.Sub(f.gapSince) >= f.CommitLag
+}
+
+// Subscribe registers a new live subscriber. When resuming, every event after lastEventID that was already delivered
+// to live subscribers is returned as well, so nothing is missed nor duplicated, unless there are more than MaxReplay
+// of them (see ErrTooFarBehind). The live channel is closed on Unsubscribe or when the subscriber falls too far behind.
+func (f *Feed) Subscribe(lastEventID uint64, resume bool) (replay []Event, live chan Event, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if resume && lastEventID < f.last {
+		if replay, err = f.find(lastEventID, f.last, MaxReplay+1); err != nil {
+			return nil, nil, err
+		}
+
+		if len(replay) > MaxReplay {
+			return nil, nil, ErrTooFarBehind
+		}
+	}
+
+	live = make(chan Event, subscriberBuffer)
+	f.subscribers[live] = struct{}{}
+	return replay, live, nil
+}
+
+// Since loads up to MaxReplay events after lastEventID among the ones already delivered to live subscribers, so those
+// too far behind to resume can catch up page by page.
+func (f *Feed) Since(lastEventID uint64) ([]Event, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if lastEventID >= f.last {
+		return nil, nil
+	}
+
+	return f.find(lastEventID, f.last, MaxReplay)
+}
+
+// Unsubscribe stops delivering events to the provided subscriber.
+func (f *Feed) Unsubscribe(live chan Event) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.remove(live)
+}
+
+func (f *Feed) remove(subscriber chan Event) {
+	if _, found := f.subscribers[subscriber]; found {
+		delete(f.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+// find loads up to limit events after the provided ID, up to the provided ID when it is not zero.
+func (f *Feed) find(after uint64, upTo uint64, limit int) ([]Event, error) {
+	query := f.db.Where("id > ?", after)
+	if upTo > 0 {
+		query = query.Where("id <= ?", upTo)
+	}
+
+	var events []Event
+	err := query.Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Kind tells which operators a field accepts: only numbers can be ordered, and only text can be searched with contains.
+type Kind int
+
+const (
+	// Text fields accept eq, ne, in and contains.
+	Text Kind = iota
+	// Number fields accept eq, ne, in, gt, ge, lt and le.
+	Number
+	// Enum fields accept eq, ne and in, with values checked against the enum.
+	Enum
+)
+
+// Field maps a filterable name to its column, parsing values before they reach the database.
+type Field struct {
+	Column string
+	Kind   Kind
+	parse  func(string) (interface{}, error)
+}
+
+// TextField filters a text column.
+func TextField(column string) Field {
+	return Field{column, Text, func(value string) (interface{}, error) { return value, nil }}
+}
+
+// NumberField filters an integer column.
+func NumberField(column string) Field {
+	return Field{column, Number, func(value string) (interface{}, error) {
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s should be a number. Invalid value received: %q", column, value)
+		}
+		return number, nil
+	}}
+}
+
+// EnumField filters an enum column, such as heroes.Role with heroes.ParseRole.
+func EnumField[T ~string](column string, parse func(string) (T, error)) Field {
+	return Field{column, Enum, func(value string) (interface{}, error) { return parse(value) }}
+}
+
+// Fields whitelists what can be filtered, by the name used in expressions. Anything else is rejected.
+type Fields map[string]Field
+
+// Names of every filterable field, sorted.
+func (f Fields) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Clause translates the parsed expression into a gorm clause, after checking every field, operator and value.
+func (f Fields) Clause(node Node) (clause.Expression, error) {
+	// gorm already wraps the root condition in parentheses when needed.
+	return f.clause(node, false)
+}
+
+func (f Fields) clause(node Node, nested bool) (clause.Expression, error) {
+	format := "%s"
+	if nested {
+		format = "(%s)"
+	}
+
+	switch node := node.(type) {
+	case And:
+		return f.combine(fmt.Sprintf(format, "? AND ?"), node.Left, node.Right)
+	case Or:
+		return f.combine(fmt.Sprintf(format, "? OR ?"), node.Left, node.Right)
+	case Not:
+		return f.combine("NOT (?)", node.Operand)
+	case Comparison:
+		return f.comparison(node)
+	default:
+		return nil, fmt.Errorf("unsupported filter node %T", node)
+	}
+}
+
+func (f Fields) combine(sql string, nodes ...Node) (clause.Expression, error) {
+	vars := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		expression, err := f.clause(node, true)
+		if err != nil {
+			return nil, err
+		}
+		vars[i] = expression
+	}
+
+	return clause.Expr{SQL: sql, Vars: vars}, nil
+}
+
+func (f Fields) comparison(c Comparison) (clause.Expression, error) {
+	field, ok := f[strings.ToLower(c.Field)]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q, filterable fields are: %s", c.Field, strings.Join(f.Names(), ", "))
+	}
+
+	if !field.accepts(c.Operator) {
+		return nil, fmt.Errorf("field %s does not support the %s operator", c.Field, c.Operator)
+	}
+
+	values := make([]interface{}, len(c.Values))
+	for i, value := range c.Values {
+		parsed, err := field.parse(value)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = parsed
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: field.Column}
+	switch c.Operator {
+	case Eq:
+		return clause.Eq{Column: column, Value: values[0]}, nil
+	case Ne:
+		return clause.Neq{Column: column, Value: values[0]}, nil
+	case Gt:
+		return clause.Gt{Column: column, Value: values[0]}, nil
+	case Ge:
+		return clause.Gte{Column: column, Value: values[0]}, nil
+	case Lt:
+		return clause.Lt{Column: column, Value: values[0]}, nil
+	case Le:
+		return clause.Lte{Column: column, Value: values[0]}, nil
+	case In:
+		return clause.IN{Column: column, Values: values}, nil
+	default:
+		// Contains is case insensitive, matching LIKE wildcards literally.
+		pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(c.Values[0])
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, "%" + pattern + "%"}}, nil
+	}
+}
+
+func (f Field) accepts(operator Operator) bool {
+	switch operator {
+	case Eq, Ne, In:
+		return true
+	case Contains:
+		return f.Kind == Text
+	default:
+		return f.Kind == Number
+	}
+}
@@ -0,0 +1,182 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_Parse(t *testing.T) {
+	a, b, c := Comparison{"a", Eq, []string{"1"}}, Comparison{"b", Eq, []string{"2"}}, Comparison{"c", Eq, []string{"3"}}
+	tests := []struct {
+		expression string
+		expected   Node
+	}{
+		{"type eq spell", Comparison{"type", Eq, []string{"spell"}}},
+		{"NAME Contains 'War Cry'", Comparison{"NAME", Contains, []string{"War Cry"}}},
+		{"level in (advanced, 'master',3)", Comparison{"level", In, []string{"advanced", "master", "3"}}},
+		{"mana ge 10 and mana LT 20", And{Comparison{"mana", Ge, []string{"10"}}, Comparison{"mana", Lt, []string{"20"}}}},
+		{"(a eq 1)or(b eq 2)", Or{a, b}},
+		{"  a eq 1  ", a},
+
+		// And binds tighter than or, and not tighter than both.
+		{"a eq 1 or b eq 2 and c eq 3", Or{a, And{b, c}}},
+		{"a eq 1 and b eq 2 or c eq 3", Or{And{a, b}, c}},
+		{"(a eq 1 or b eq 2) and c eq 3", And{Or{a, b}, c}},
+		{"not a eq 1 and b eq 2", And{Not{a}, b}},
+		{"not (a eq 1 and b eq 2)", Not{And{a, b}}},
+		{"a eq 1 or b eq 2 or c eq 3", Or{Or{a, b}, c}},
+
+		// Quotes are escaped by doubling them, and the other quote needs no escaping.
+		{"name eq 'Hero''s Call'", Comparison{"name", Eq, []string{"Hero's Call"}}},
+		{`name eq "say ""hi"""`, Comparison{"name", Eq, []string{`say "hi"`}}},
+		{`name eq "Hero's Call"`, Comparison{"name", Eq, []string{"Hero's Call"}}},
+		{"name eq ''", Comparison{"name", Eq, []string{""}}},
+		{"name eq 'a, (b) and c'", Comparison{"name", Eq, []string{"a, (b) and c"}}},
+	}
+
+	for _, test := range tests {
+		node, err := Parse(test.expression)
+		if err != nil || !reflect.DeepEqual(node, test.expected) {
+			t.Errorf("Expected %q to parse as %v, got: %v (%v)", test.expression, test.expected, node, err)
+		}
+	}
+}
+
+func Test_Parse_ERRORS(t *testing.T) {
+	tests := []struct {
+		expression string
+		position   int
+		message    string
+	}{
+		{"", 1, "expected a field name"},
+		{"name eq 'war", 9, "unterminated quoted value"},
+		{"name", 5, "expected an operator"},
+		{"name like war", 6, "expected an operator"},
+		{"name eq", 8, "expected a value"},
+		{"name eq (war)", 9, "expected a value"},
+		{"(name eq war", 13, "expected ) but found"},
+		{"name eq war)", 12, `unexpected ")"`},
+		{"name eq war cry", 13, `unexpected "cry"`},
+		{"name in war", 9, "expected ( after in"},
+		{"name in (war cry)", 14, "expected , or )"},
+		{"name eq war and", 16, "expected a field name"},
+		{strings.Repeat(" ", MaxLength+1), MaxLength, "limited to 1000 characters"},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.expression)
+
+		var syntaxError *SyntaxError
+		if !errors.As(err, &syntaxError) || syntaxError.Position != test.position || !strings.Contains(syntaxError.Message, test.message) {
+			t.Errorf("Expected %q to fail at position %d with %q, got: %v", test.expression, test.position, test.message, err)
+		}
+	}
+
+	if _, err := Parse("name eq " + strings.Repeat("a", MaxLength-8)); err != nil {
+		t.Error("Expected expressions up to MaxLength to parse, got:", err)
+	}
+}
+
+type color string
+
+func parseColor(value string) (color, error) {
+	if value != "red" && value != "blue" {
+		return "", fmt.Errorf("color should be one of red, blue. Invalid value received: %q", value)
+	}
+	return color(value), nil
+}
+
+var fields = Fields{
+	"name":  TextField("name"),
+	"mana":  NumberField("mana_cost"),
+	"color": EnumField("color", parseColor),
+}
+
+func Test_Fields_Clause(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"name eq 'War Cry'", `"skills"."name" = 'War Cry'`},
+		{"NAME ne war", `"skills"."name" <> 'war'`},
+		{"mana gt 1 and mana ge 2 and mana lt 9 and mana le 8", `(("skills"."mana_cost" > 1 AND "skills"."mana_cost" >= 2) AND "skills"."mana_cost" < 9) AND "skills"."mana_cost" <= 8`},
+		{"color in (red, blue)", `"skills"."color" IN ('red','blue')`},
+		{"name eq x or name eq y and not color eq red", `"skills"."name" = 'x' OR ("skills"."name" = 'y' AND NOT ("skills"."color" = 'red'))`},
+		{"(name eq x or name eq y) and mana eq 3", `("skills"."name" = 'x' OR "skills"."name" = 'y') AND "skills"."mana_cost" = 3`},
+
+		// Contains matches LIKE wildcards and backslashes literally.
+		{"name contains fire", `"skills"."name" ILIKE '%fire%'`},
+		{`name contains '100%_\'`, `"skills"."name" ILIKE '%100\%\_\\%'`},
+	}
+
+	db := dryRun(t)
+	for _, test := range tests {
+		node, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %v", test.expression, err)
+		}
+
+		condition, err := fields.Clause(node)
+		if err != nil {
+			t.Errorf("Expected %q to be a valid clause, got: %v", test.expression, err)
+			continue
+		}
+
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Table("skills").Where(condition).Find(&[]map[string]interface{}{})
+		})
+		if expected := `SELECT * FROM "skills" WHERE ` + test.expected; sql != expected {
+			t.Errorf("Expected %q to be\n%s\ngot:\n%s", test.expression, expected, sql)
+		}
+	}
+}
+
+func Test_Fields_Clause_ERRORS(t *testing.T) {
+	tests := []struct {
+		expression string
+		message    string
+	}{
+		{"level eq 1", `unknown field "level", filterable fields are: color, mana, name`},
+		{"name eq x and not (power gt 1)", `unknown field "power"`},
+		{"name gt x", "field name does not support the gt operator"},
+		{"mana contains 1", "field mana does not support the contains operator"},
+		{"color le red", "field color does not support the le operator"},
+		{"mana eq one", `mana_cost should be a number. Invalid value received: "one"`},
+		{"mana in (1, two)", `Invalid value received: "two"`},
+		{"color eq green", `color should be one of red, blue. Invalid value received: "green"`},
+	}
+
+	for _, test := range tests {
+		node, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %v", test.expression, err)
+		}
+
+		if _, err := fields.Clause(node); err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("Expected %q to fail with %q, got: %v", test.expression, test.message, err)
+		}
+	}
+}
+
+// dryRun opens a database that only builds SQL, never running it.
+func dryRun(t *testing.T) *gorm.DB {
+	conn, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Unable to open a stub database connection:", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal("Unable to open gorm:", err)
+	}
+
+	return db
+}
@@ -0,0 +1,74 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type kind int
+
+const (
+	tokenEnd kind = iota
+	tokenWord
+	tokenQuoted
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind kind
+	text string
+	pos  int
+}
+
+// lex splits the expression into tokens. Positions are 1-based, so they read naturally in error messages.
+func lex(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "(", i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")", i + 1})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", i + 1})
+			i++
+		case r == '\'' || r == '"':
+			// Quotes are escaped by doubling them, as in SQL: 'Hero''s Call'.
+			var text strings.Builder
+			start := i
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, &SyntaxError{start + 1, "unterminated quoted value"}
+				}
+
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						i++
+					} else {
+						break
+					}
+				}
+				text.WriteRune(runes[i])
+			}
+			tokens = append(tokens, token{tokenQuoted, text.String(), start + 1})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("(),'\"", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i]), start + 1})
+		}
+	}
+
+	return append(tokens, token{tokenEnd, "end of filter", len(runes) + 1}), nil
+}
@@ -0,0 +1,249 @@
+// Package filter parses the ?filter= expressions accepted by list routes, such as
+//
+//	type eq spell and level_requirement in (advanced, master)
+//
+// into an AST, which Fields then translates into gorm clauses after checking it against a whitelist.
+//
+// Comparisons are "field operator value", where operators are eq, ne, gt, ge, lt, le, contains and in, the latter
+// taking a parenthesized list of values. They can be combined with and, or, not and parentheses. Values are bare words
+// or quoted strings, such as 'War Cry'. Keywords are case insensitive.
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLength caps how long an expression can be, as a cheap guard against abusive queries.
+const MaxLength = 1000
+
+// Node is any element of a parsed expression.
+type Node interface {
+	node()
+}
+
+// And matches when both sides match.
+type And struct {
+	Left, Right Node
+}
+
+// Or matches when any side matches.
+type Or struct {
+	Left, Right Node
+}
+
+// Not matches when its operand doesn't.
+type Not struct {
+	Operand Node
+}
+
+// Comparison compares a field against one value, or a list of values for In.
+type Comparison struct {
+	Field    string
+	Operator Operator
+	Values   []string
+}
+
+func (And) node()        {}
+func (Or) node()         {}
+func (Not) node()        {}
+func (Comparison) node() {}
+
+// Operator compares a field against values.
+type Operator string
+
+// Operators understood by Parse.
+const (
+	Eq       Operator = "eq"
+	Ne       Operator = "ne"
+	Gt       Operator = "gt"
+	Ge       Operator = "ge"
+	Lt       Operator = "lt"
+	Le       Operator = "le"
+	Contains Operator = "contains"
+	In       Operator = "in"
+)
+
+var operators = map[string]Operator{"eq": Eq, "ne": Ne, "gt": Gt, "ge": Ge, "lt": Lt, "le": Le, "contains": Contains, "in": In}
+
+// SyntaxError tells where an expression stopped making sense.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
+
+// Parse reads an expression. Field names and values are only checked later, by Fields.
+func Parse(expression string) (Node, error) {
+	if len(expression) > MaxLength {
+		return nil, &SyntaxError{MaxLength, fmt.Sprintf("filters are limited to %d characters", MaxLength)}
+	}
+
+	tokens, err := lex(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, p.errorf(next, "unexpected %q", next.text)
+	}
+
+	return node, nil
+}
+
+// AndAll combines nodes with And, skipping nil ones. It returns nil when every node is nil.
+func AndAll(nodes ...Node) Node {
+	var combined Node
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		if combined == nil {
+			combined = node
+		} else {
+			combined = And{combined, node}
+		}
+	}
+
+	return combined
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+
+	return t
+}
+
+// keyword consumes the next token when it is the provided bare word.
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{t.pos, fmt.Sprintf(format, args...)}
+}
+
+// or := and ("or" and)*
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	for err == nil && p.keyword("or") {
+		var right Node
+		if right, err = p.and(); err == nil {
+			left = Or{left, right}
+		}
+	}
+
+	return left, err
+}
+
+// and := unary ("and" unary)*
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	for err == nil && p.keyword("and") {
+		var right Node
+		if right, err = p.unary(); err == nil {
+			left = And{left, right}
+		}
+	}
+
+	return left, err
+}
+
+// unary := "not" unary | "(" or ")" | comparison
+func (p *parser) unary() (Node, error) {
+	if p.keyword("not") {
+		operand, err := p.unary()
+		return Not{operand}, err
+	}
+
+	if p.peek().kind == tokenOpen {
+		p.next()
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.next(); t.kind != tokenClose {
+			return nil, p.errorf(t, "expected ) but found %q", t.text)
+		}
+		return node, nil
+	}
+
+	return p.comparison()
+}
+
+// comparison := field operator value | field "in" "(" value ("," value)* ")"
+func (p *parser) comparison() (Node, error) {
+	field := p.next()
+	if field.kind != tokenWord {
+		return nil, p.errorf(field, "expected a field name but found %q", field.text)
+	}
+
+	t := p.next()
+	operator, ok := operators[strings.ToLower(t.text)]
+	if t.kind != tokenWord || !ok {
+		return nil, p.errorf(t, "expected an operator (eq, ne, gt, ge, lt, le, contains or in) but found %q", t.text)
+	}
+
+	if operator != In {
+		value, err := p.value()
+		return Comparison{field.text, operator, []string{value}}, err
+	}
+
+	if t := p.next(); t.kind != tokenOpen {
+		return nil, p.errorf(t, "expected ( after in but found %q", t.text)
+	}
+
+	var values []string
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch t := p.next(); t.kind {
+		case tokenComma:
+		case tokenClose:
+			return Comparison{field.text, operator, values}, nil
+		default:
+			return nil, p.errorf(t, "expected , or ) but found %q", t.text)
+		}
+	}
+}
+
+func (p *parser) value() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenQuoted {
+		return "", p.errorf(t, "expected a value but found %q", t.text)
+	}
+
+	return t.text, nil
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"gorm.io/gorm"
+)
+
+// association is a many2many relation between heroes entities, as declared by their gorm tags.
+type association struct {
+	owner     string
+	joinTable string
+	ownerKey  string
+	targetKey string
+}
+
+var (
+	raceStartingSkills     = association{"races", "race_starting_skills", "race_id", "skill_id"}
+	raceAvailableSkills    = association{"races", "race_available_skills", "race_id", "skill_id"}
+	raceRecommendedClasses = association{"races", "race_recommended_classes", "race_id", "class_id"}
+	classProficiencies     = association{"classes", "class_proficiencies", "class_id", "proficiency_id"}
+	classStartingSkills    = association{"classes", "class_starting_skills", "class_id", "skill_id"}
+	classAvailableSkills   = association{"classes", "class_available_skills", "class_id", "skill_id"}
+	skillRequirements      = association{"skills", "skill_requirements", "skill_id", "skill_requirement_id"}
+)
+
+// link is a single row of a join table.
+type link struct {
+	OwnerID  uint64
+	TargetID uint64
+}
+
+type loaderKey struct{}
+
+// Loader batches association lookups of a single GraphQL request to avoid N+1 queries.
+// Every entity resolved so far is remembered by Seen. The first time an association of an entity is needed, it is
+// loaded at once for every entity of the same type seen without it, with one query on the join table and one on the
+// associated entities. A request then costs a couple of queries per association and nesting level, instead of per entity.
+type Loader struct {
+	db *gorm.DB
+
+	mutex         sync.Mutex
+	seen          map[string][]uint64
+	links         map[association]map[uint64][]uint64
+	skills        map[uint64]heroes.Skill
+	classes       map[uint64]heroes.Class
+	proficiencies map[uint64]heroes.Proficiency
+}
+
+// NewLoader constructs an empty Loader. Loaders cache what they load, so they must not outlive a request.
+func NewLoader(db *gorm.DB) *Loader {
+	return &Loader{
+		db:            db,
+		seen:          make(map[string][]uint64),
+		links:         make(map[association]map[uint64][]uint64),
+		skills:        make(map[uint64]heroes.Skill),
+		classes:       make(map[uint64]heroes.Class),
+		proficiencies: make(map[uint64]heroes.Proficiency),
+	}
+}
+
+// WithLoader gives a context carrying the provided Loader, as expected by the schema resolvers.
+func WithLoader(ctx context.Context, loader *Loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func loaderFrom(ctx context.Context) *Loader {
+	return ctx.Value(loaderKey{}).(*Loader)
+}
+
+// Seen records entities of the provided table whose associations might be asked for later.
+func (l *Loader) Seen(table string, ids ...uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.seen[table] = append(l.seen[table], ids...)
+}
+
+// Skills associated to the owner through the provided association.
+func (l *Loader) Skills(a association, ownerID uint64) ([]heroes.Skill, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	targets, err := l.load(a, ownerID, func(id uint64) bool { _, found := l.skills[id]; return !found }, func(ids []uint64) error {
+		var skills []heroes.Skill
+		if err := l.db.Where("id IN ?", ids).Find(&skills).Error; err != nil {
+			return err
+		}
+
+		for _, skill := range skills {
+			l.skills[skill.ID] = skill
+			l.seen["skills"] = append(l.seen["skills"], skill.ID)
+		}
+		return nil
+	})
+
+	result := []heroes.Skill{}
+	for _, id := range targets {
+		if skill, found := l.skills[id]; found {
+			result = append(result, skill)
+		}
+	}
+
+	return result, err
+}
+
+// Classes associated to the owner through the provided association.
+func (l *Loader) Classes(a association, ownerID uint64) ([]heroes.Class, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	targets, err := l.load(a, ownerID, func(id uint64) bool { _, found := l.classes[id]; return !found }, func(ids []uint64) error {
+		var classes []heroes.Class
+		if err := l.db.Where("id IN ?", ids).Find(&classes).Error; err != nil {
+			return err
+		}
+
+		for _, class := range classes {
+			l.classes[class.ID] = class
+			l.seen["classes"] = append(l.seen["classes"], class.ID)
+		}
+		return nil
+	})
+
+	result := []heroes.Class{}
+	for _, id := range targets {
+		if class, found := l.classes[id]; found {
+			result = append(result, class)
+		}
+	}
+
+	return result, err
+}
+
+// Proficiencies associated to the owner through the provided association.
+func (l *Loader) Proficiencies(a association, ownerID uint64) ([]heroes.Proficiency, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	targets, err := l.load(a, ownerID, func(id uint64) bool { _, found := l.proficiencies[id]; return !found }, func(ids []uint64) error {
+		var proficiencies []heroes.Proficiency
+		if err := l.db.Where("id IN ?", ids).Find(&proficiencies).Error; err != nil {
+			return err
+		}
+
+		for _, proficiency := range proficiencies {
+			l.proficiencies[proficiency.ID] = proficiency
+		}
+		return nil
+	})
+
+	result := []heroes.Proficiency{}
+	for _, id := range targets {
+		if proficiency, found := l.proficiencies[id]; found {
+			result = append(result, proficiency)
+		}
+	}
+
+	return result, err
+}
+
+// load gives the IDs associated to the owner. When they are not known yet, the association is loaded for every seen
+// owner lacking it, then fetch is called once with every associated ID missing from the cache.
+func (l *Loader) load(a association, ownerID uint64, missing func(id uint64) bool, fetch func(ids []uint64) error) ([]uint64, error) {
+	links, found := l.links[a]
+	if !found {
+		links = make(map[uint64][]uint64)
+		l.links[a] = links
+	}
+
+	if targets, found := links[ownerID]; found {
+		return targets, nil
+	}
+
+	owners := []uint64{ownerID}
+	for _, id := range l.seen[a.owner] {
+		if _, found := links[id]; !found && id != ownerID {
+			owners = append(owners, id)
+		}
+	}
+
+	var rows []link
+	if err := l.db.Table(a.joinTable).Select(a.ownerKey+" AS owner_id, "+a.targetKey+" AS target_id").
+		Where(a.ownerKey+" IN ?", owners).Order(a.targetKey).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[uint64][]uint64, len(owners))
+	for _, owner := range owners {
+		loaded[owner] = []uint64{}
+	}
+
+	var wanted []uint64
+	pending := map[uint64]bool{}
+	for _, row := range rows {
+		loaded[row.OwnerID] = append(loaded[row.OwnerID], row.TargetID)
+		if missing(row.TargetID) && !pending[row.TargetID] {
+			pending[row.TargetID] = true
+			wanted = append(wanted, row.TargetID)
+		}
+	}
+
+	if len(wanted) > 0 {
+		if err := fetch(wanted); err != nil {
+			return nil, err
+		}
+	}
+
+	// Links are only kept once their entities are cached, so a failure is retried by the next lookup.
+	for owner, targets := range loaded {
+		links[owner] = targets
+	}
+
+	return loaded[ownerID], nil
+}
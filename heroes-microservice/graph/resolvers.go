@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// errUnavailable hides repository failures from clients, as REST routes do.
+var errUnavailable = errors.New("unable to process your request right now, please check with system administrator")
+
+// queryResolvers answers the top level queries through the repository, so they benefit from its cache when enabled.
+type queryResolvers struct {
+	repository database.Repository
+}
+
+func (q queryResolvers) races(p graphql.ResolveParams) (interface{}, error) {
+	var races []heroes.Race
+	if !q.repository.FindAll(&races) {
+		return nil, errUnavailable
+	}
+
+	for _, race := range races {
+		loaderFrom(p.Context).Seen("races", race.ID)
+	}
+	return races, nil
+}
+
+func (q queryResolvers) race(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var races []heroes.Race
+	if !q.repository.FindByField(&races, map[string]interface{}{"id": id}) {
+		return nil, errUnavailable
+	}
+
+	if len(races) == 0 {
+		return nil, nil
+	}
+
+	loaderFrom(p.Context).Seen("races", id)
+	return races[0], nil
+}
+
+func (q queryResolvers) classes(p graphql.ResolveParams) (interface{}, error) {
+	var classes []heroes.Class
+	var found bool
+	if role, ok := p.Args["role"].(heroes.Role); ok {
+		found = q.repository.FindByField(&classes, &heroes.Class{Role: role})
+	} else {
+		found = q.repository.FindAll(&classes)
+	}
+
+	if !found {
+		return nil, errUnavailable
+	}
+
+	for _, class := range classes {
+		loaderFrom(p.Context).Seen("classes", class.ID)
+	}
+	return classes, nil
+}
+
+func (q queryResolvers) class(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var classes []heroes.Class
+	if !q.repository.FindByField(&classes, map[string]interface{}{"id": id}) {
+		return nil, errUnavailable
+	}
+
+	if len(classes) == 0 {
+		return nil, nil
+	}
+
+	loaderFrom(p.Context).Seen("classes", id)
+	return classes[0], nil
+}
+
+func (q queryResolvers) skills(p graphql.ResolveParams) (interface{}, error) {
+	filter := heroes.Skill{}
+	if skillType, ok := p.Args["type"].(heroes.SkillType); ok {
+		filter.Type = skillType
+	}
+
+	if source, ok := p.Args["source"].(heroes.Source); ok {
+		filter.Source = source
+	}
+
+	var skills []heroes.Skill
+	if !q.repository.FindByField(&skills, &filter) {
+		return nil, errUnavailable
+	}
+
+	for _, skill := range skills {
+		loaderFrom(p.Context).Seen("skills", skill.ID)
+	}
+	return skills, nil
+}
+
+func (q queryResolvers) skill(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []heroes.Skill
+	if !q.repository.FindByField(&skills, map[string]interface{}{"id": id}) {
+		return nil, errUnavailable
+	}
+
+	if len(skills) == 0 {
+		return nil, nil
+	}
+
+	loaderFrom(p.Context).Seen("skills", id)
+	return skills[0], nil
+}
+
+func (q queryResolvers) proficiencies(p graphql.ResolveParams) (interface{}, error) {
+	var proficiencies []heroes.Proficiency
+	if !q.repository.FindAll(&proficiencies) {
+		return nil, errUnavailable
+	}
+
+	return proficiencies, nil
+}
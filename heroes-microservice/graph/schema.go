@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+// NewSchema builds the GraphQL schema over the heroes domain. Top level queries go through the provided Repository,
+// while associations are loaded by the Loader found in the request context (see WithLoader).
+func NewSchema(repository database.Repository) (graphql.Schema, error) {
+	role := enum[heroes.Role]()
+	proficiencyType := enum[heroes.ProficiencyType]()
+	difficultyType := enum[heroes.DifficultyType]()
+	activation := enum[heroes.Activation]()
+	source := enum[heroes.Source]()
+	skillType := enum[heroes.SkillType]()
+	levelRequirement := enum[heroes.LevelRequirement]()
+
+	attributes := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Attributes",
+		Fields: graphql.Fields{
+			"strength":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"agility":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"intelligence": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"willpower":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	proficiency := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Proficiency",
+		Fields: withMetadata(graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(proficiencyType)},
+		}),
+	})
+
+	var skill *graphql.Object
+	skill = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Skill",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return withMetadata(graphql.Fields{
+				"name":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"slug":             promoted(graphql.NewNonNull(graphql.String), "Slug"),
+				"description":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"bonus":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"mana":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"difficultyType":   &graphql.Field{Type: difficultyType},
+				"difficulty":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"activation":       &graphql.Field{Type: activation},
+				"source":           &graphql.Field{Type: source},
+				"type":             &graphql.Field{Type: skillType},
+				"levelRequirement": &graphql.Field{Type: levelRequirement},
+				"observations":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"skillRequirements": skills(skill, func(p graphql.ResolveParams) (interface{}, error) {
+					return loaderFrom(p.Context).Skills(skillRequirements, p.Source.(heroes.Skill).ID)
+				}),
+			})
+		}),
+	})
+
+	class := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Class",
+		Fields: withMetadata(graphql.Fields{
+			"name":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"slug":            promoted(graphql.NewNonNull(graphql.String), "Slug"),
+			"description":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"bonusAttributes": &graphql.Field{Type: graphql.NewNonNull(attributes)},
+			"role":            &graphql.Field{Type: role},
+			"proficiencies": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(proficiency))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loaderFrom(p.Context).Proficiencies(classProficiencies, p.Source.(heroes.Class).ID)
+				},
+			},
+			"startingSkills": skills(skill, func(p graphql.ResolveParams) (interface{}, error) {
+				return loaderFrom(p.Context).Skills(classStartingSkills, p.Source.(heroes.Class).ID)
+			}),
+			"availableSkills": skills(skill, func(p graphql.ResolveParams) (interface{}, error) {
+				return loaderFrom(p.Context).Skills(classAvailableSkills, p.Source.(heroes.Class).ID)
+			}),
+		}),
+	})
+
+	race := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Race",
+		Fields: withMetadata(graphql.Fields{
+			"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"slug":           promoted(graphql.NewNonNull(graphql.String), "Slug"),
+			"description":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"baseAttributes": &graphql.Field{Type: graphql.NewNonNull(attributes)},
+			"startingSkills": skills(skill, func(p graphql.ResolveParams) (interface{}, error) {
+				return loaderFrom(p.Context).Skills(raceStartingSkills, p.Source.(heroes.Race).ID)
+			}),
+			"availableSkills": skills(skill, func(p graphql.ResolveParams) (interface{}, error) {
+				return loaderFrom(p.Context).Skills(raceAvailableSkills, p.Source.(heroes.Race).ID)
+			}),
+			"recommendedClasses": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(class))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loaderFrom(p.Context).Classes(raceRecommendedClasses, p.Source.(heroes.Race).ID)
+				},
+			},
+		}),
+	})
+
+	resolvers := queryResolvers{repository}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"races": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(race))),
+				Resolve: resolvers.races,
+			},
+			"race": &graphql.Field{
+				Type:    race,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolvers.race,
+			},
+			"classes": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(class))),
+				Args:    graphql.FieldConfigArgument{"role": &graphql.ArgumentConfig{Type: role}},
+				Resolve: resolvers.classes,
+			},
+			"class": &graphql.Field{
+				Type:    class,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolvers.class,
+			},
+			"skills": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(skill))),
+				Args:    graphql.FieldConfigArgument{"type": &graphql.ArgumentConfig{Type: skillType}, "source": &graphql.ArgumentConfig{Type: source}},
+				Resolve: resolvers.skills,
+			},
+			"skill": &graphql.Field{
+				Type:    skill,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolvers.skill,
+			},
+			"proficiencies": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(proficiency))),
+				Resolve: resolvers.proficiencies,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// enum builds a GraphQL enum named after the Go type, whose values are named after the uppercased allowed values.
+func enum[T ~string]() *graphql.Enum {
+	config := graphql.EnumValueConfigMap{}
+	for _, value := range heroes.Values[T]() {
+		config[strings.ToUpper(string(value))] = &graphql.EnumValueConfig{Value: value}
+	}
+
+	return graphql.NewEnum(graphql.EnumConfig{Name: reflect.TypeOf(T("")).Name(), Values: config})
+}
+
+func skills(skill *graphql.Object, resolve graphql.FieldResolveFn) *graphql.Field {
+	return &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(skill))), Resolve: resolve}
+}
+
+// withMetadata adds the fields every entity has, which the default resolver can't find in embedded structs.
+func withMetadata(fields graphql.Fields) graphql.Fields {
+	fields["id"] = &graphql.Field{Type: graphql.NewNonNull(graphql.ID)}
+	fields["version"] = promoted(graphql.NewNonNull(graphql.Int), "Version")
+	fields["createdAt"] = promoted(graphql.NewNonNull(graphql.DateTime), "CreatedAt")
+	fields["updatedAt"] = promoted(graphql.NewNonNull(graphql.DateTime), "UpdatedAt")
+
+	return fields
+}
+
+// promoted resolves a field promoted from an embedded struct, such as heroes.Timestamps or heroes.Revision.
+func promoted(fieldType graphql.Output, name string) *graphql.Field {
+	return &graphql.Field{
+		Type: fieldType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return reflect.ValueOf(p.Source).FieldByName(name).Interface(), nil
+		},
+	}
+}
+
+func parseID(p graphql.ResolveParams) (uint64, error) {
+	return strconv.ParseUint(p.Args["id"].(string), 10, 64)
+}
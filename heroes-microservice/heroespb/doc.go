@@ -0,0 +1,5 @@
+// Package heroespb holds the gRPC definition of the heroes service, along with the code generated from it.
+// Run go generate after changing heroes.proto. It needs buf, protoc-gen-go and protoc-gen-go-grpc on PATH.
+package heroespb
+
+//go:generate buf generate
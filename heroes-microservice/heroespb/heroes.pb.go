@@ -0,0 +1,2101 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: heroes.proto
+
+package heroespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role mirrors heroes.Role.
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_FIGHTER     Role = 1
+	Role_ROLE_SPELLCASTER Role = 2
+	Role_ROLE_DEXTEROUS   Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_FIGHTER",
+		2: "ROLE_SPELLCASTER",
+		3: "ROLE_DEXTEROUS",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_FIGHTER":     1,
+		"ROLE_SPELLCASTER": 2,
+		"ROLE_DEXTEROUS":   3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{0}
+}
+
+// ProficiencyType mirrors heroes.ProficiencyType.
+type ProficiencyType int32
+
+const (
+	ProficiencyType_PROFICIENCY_TYPE_UNSPECIFIED     ProficiencyType = 0
+	ProficiencyType_PROFICIENCY_TYPE_SIMPLE_WEAPONS  ProficiencyType = 1
+	ProficiencyType_PROFICIENCY_TYPE_COMPLEX_WEAPONS ProficiencyType = 2
+	ProficiencyType_PROFICIENCY_TYPE_CAST_MAGIC      ProficiencyType = 3
+	ProficiencyType_PROFICIENCY_TYPE_READ_MAGIC      ProficiencyType = 4
+	ProficiencyType_PROFICIENCY_TYPE_PICKPOCKET      ProficiencyType = 5
+)
+
+// Enum value maps for ProficiencyType.
+var (
+	ProficiencyType_name = map[int32]string{
+		0: "PROFICIENCY_TYPE_UNSPECIFIED",
+		1: "PROFICIENCY_TYPE_SIMPLE_WEAPONS",
+		2: "PROFICIENCY_TYPE_COMPLEX_WEAPONS",
+		3: "PROFICIENCY_TYPE_CAST_MAGIC",
+		4: "PROFICIENCY_TYPE_READ_MAGIC",
+		5: "PROFICIENCY_TYPE_PICKPOCKET",
+	}
+	ProficiencyType_value = map[string]int32{
+		"PROFICIENCY_TYPE_UNSPECIFIED":     0,
+		"PROFICIENCY_TYPE_SIMPLE_WEAPONS":  1,
+		"PROFICIENCY_TYPE_COMPLEX_WEAPONS": 2,
+		"PROFICIENCY_TYPE_CAST_MAGIC":      3,
+		"PROFICIENCY_TYPE_READ_MAGIC":      4,
+		"PROFICIENCY_TYPE_PICKPOCKET":      5,
+	}
+)
+
+func (x ProficiencyType) Enum() *ProficiencyType {
+	p := new(ProficiencyType)
+	*p = x
+	return p
+}
+
+func (x ProficiencyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProficiencyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[1].Descriptor()
+}
+
+func (ProficiencyType) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[1]
+}
+
+func (x ProficiencyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProficiencyType.Descriptor instead.
+func (ProficiencyType) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{1}
+}
+
+// DifficultyType mirrors heroes.DifficultyType.
+type DifficultyType int32
+
+const (
+	DifficultyType_DIFFICULTY_TYPE_UNSPECIFIED DifficultyType = 0
+	DifficultyType_DIFFICULTY_TYPE_AUTO        DifficultyType = 1
+	DifficultyType_DIFFICULTY_TYPE_FIXED       DifficultyType = 2
+	DifficultyType_DIFFICULTY_TYPE_VARIABLE    DifficultyType = 3
+	DifficultyType_DIFFICULTY_TYPE_TARGET_PLUS DifficultyType = 4
+)
+
+// Enum value maps for DifficultyType.
+var (
+	DifficultyType_name = map[int32]string{
+		0: "DIFFICULTY_TYPE_UNSPECIFIED",
+		1: "DIFFICULTY_TYPE_AUTO",
+		2: "DIFFICULTY_TYPE_FIXED",
+		3: "DIFFICULTY_TYPE_VARIABLE",
+		4: "DIFFICULTY_TYPE_TARGET_PLUS",
+	}
+	DifficultyType_value = map[string]int32{
+		"DIFFICULTY_TYPE_UNSPECIFIED": 0,
+		"DIFFICULTY_TYPE_AUTO":        1,
+		"DIFFICULTY_TYPE_FIXED":       2,
+		"DIFFICULTY_TYPE_VARIABLE":    3,
+		"DIFFICULTY_TYPE_TARGET_PLUS": 4,
+	}
+)
+
+func (x DifficultyType) Enum() *DifficultyType {
+	p := new(DifficultyType)
+	*p = x
+	return p
+}
+
+func (x DifficultyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DifficultyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[2].Descriptor()
+}
+
+func (DifficultyType) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[2]
+}
+
+func (x DifficultyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DifficultyType.Descriptor instead.
+func (DifficultyType) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{2}
+}
+
+// Activation mirrors heroes.Activation.
+type Activation int32
+
+const (
+	Activation_ACTIVATION_UNSPECIFIED Activation = 0
+	Activation_ACTIVATION_ACTION      Activation = 1
+	Activation_ACTIVATION_REACTION    Activation = 2
+	Activation_ACTIVATION_PASSIVE     Activation = 3
+)
+
+// Enum value maps for Activation.
+var (
+	Activation_name = map[int32]string{
+		0: "ACTIVATION_UNSPECIFIED",
+		1: "ACTIVATION_ACTION",
+		2: "ACTIVATION_REACTION",
+		3: "ACTIVATION_PASSIVE",
+	}
+	Activation_value = map[string]int32{
+		"ACTIVATION_UNSPECIFIED": 0,
+		"ACTIVATION_ACTION":      1,
+		"ACTIVATION_REACTION":    2,
+		"ACTIVATION_PASSIVE":     3,
+	}
+)
+
+func (x Activation) Enum() *Activation {
+	p := new(Activation)
+	*p = x
+	return p
+}
+
+func (x Activation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Activation) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[3].Descriptor()
+}
+
+func (Activation) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[3]
+}
+
+func (x Activation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Activation.Descriptor instead.
+func (Activation) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{3}
+}
+
+// Source mirrors heroes.Source.
+type Source int32
+
+const (
+	Source_SOURCE_UNSPECIFIED Source = 0
+	Source_SOURCE_BASE        Source = 1
+	Source_SOURCE_RACE        Source = 2
+	Source_SOURCE_CLASS       Source = 3
+	Source_SOURCE_ANCESTOR    Source = 4
+)
+
+// Enum value maps for Source.
+var (
+	Source_name = map[int32]string{
+		0: "SOURCE_UNSPECIFIED",
+		1: "SOURCE_BASE",
+		2: "SOURCE_RACE",
+		3: "SOURCE_CLASS",
+		4: "SOURCE_ANCESTOR",
+	}
+	Source_value = map[string]int32{
+		"SOURCE_UNSPECIFIED": 0,
+		"SOURCE_BASE":        1,
+		"SOURCE_RACE":        2,
+		"SOURCE_CLASS":       3,
+		"SOURCE_ANCESTOR":    4,
+	}
+)
+
+func (x Source) Enum() *Source {
+	p := new(Source)
+	*p = x
+	return p
+}
+
+func (x Source) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Source) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[4].Descriptor()
+}
+
+func (Source) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[4]
+}
+
+func (x Source) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Source.Descriptor instead.
+func (Source) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{4}
+}
+
+// SkillType mirrors heroes.SkillType.
+type SkillType int32
+
+const (
+	SkillType_SKILL_TYPE_UNSPECIFIED    SkillType = 0
+	SkillType_SKILL_TYPE_ABILITY        SkillType = 1
+	SkillType_SKILL_TYPE_CHARACTERISTIC SkillType = 2
+	SkillType_SKILL_TYPE_TECHNIQUE      SkillType = 3
+	SkillType_SKILL_TYPE_SPELL          SkillType = 4
+)
+
+// Enum value maps for SkillType.
+var (
+	SkillType_name = map[int32]string{
+		0: "SKILL_TYPE_UNSPECIFIED",
+		1: "SKILL_TYPE_ABILITY",
+		2: "SKILL_TYPE_CHARACTERISTIC",
+		3: "SKILL_TYPE_TECHNIQUE",
+		4: "SKILL_TYPE_SPELL",
+	}
+	SkillType_value = map[string]int32{
+		"SKILL_TYPE_UNSPECIFIED":    0,
+		"SKILL_TYPE_ABILITY":        1,
+		"SKILL_TYPE_CHARACTERISTIC": 2,
+		"SKILL_TYPE_TECHNIQUE":      3,
+		"SKILL_TYPE_SPELL":          4,
+	}
+)
+
+func (x SkillType) Enum() *SkillType {
+	p := new(SkillType)
+	*p = x
+	return p
+}
+
+func (x SkillType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SkillType) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[5].Descriptor()
+}
+
+func (SkillType) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[5]
+}
+
+func (x SkillType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SkillType.Descriptor instead.
+func (SkillType) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{5}
+}
+
+// LevelRequirement mirrors heroes.LevelRequirement.
+type LevelRequirement int32
+
+const (
+	LevelRequirement_LEVEL_REQUIREMENT_UNSPECIFIED LevelRequirement = 0
+	LevelRequirement_LEVEL_REQUIREMENT_NONE        LevelRequirement = 1
+	LevelRequirement_LEVEL_REQUIREMENT_ADVANCED    LevelRequirement = 2
+	LevelRequirement_LEVEL_REQUIREMENT_MASTER      LevelRequirement = 3
+	LevelRequirement_LEVEL_REQUIREMENT_INITIAL     LevelRequirement = 4
+)
+
+// Enum value maps for LevelRequirement.
+var (
+	LevelRequirement_name = map[int32]string{
+		0: "LEVEL_REQUIREMENT_UNSPECIFIED",
+		1: "LEVEL_REQUIREMENT_NONE",
+		2: "LEVEL_REQUIREMENT_ADVANCED",
+		3: "LEVEL_REQUIREMENT_MASTER",
+		4: "LEVEL_REQUIREMENT_INITIAL",
+	}
+	LevelRequirement_value = map[string]int32{
+		"LEVEL_REQUIREMENT_UNSPECIFIED": 0,
+		"LEVEL_REQUIREMENT_NONE":        1,
+		"LEVEL_REQUIREMENT_ADVANCED":    2,
+		"LEVEL_REQUIREMENT_MASTER":      3,
+		"LEVEL_REQUIREMENT_INITIAL":     4,
+	}
+)
+
+func (x LevelRequirement) Enum() *LevelRequirement {
+	p := new(LevelRequirement)
+	*p = x
+	return p
+}
+
+func (x LevelRequirement) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LevelRequirement) Descriptor() protoreflect.EnumDescriptor {
+	return file_heroes_proto_enumTypes[6].Descriptor()
+}
+
+func (LevelRequirement) Type() protoreflect.EnumType {
+	return &file_heroes_proto_enumTypes[6]
+}
+
+func (x LevelRequirement) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LevelRequirement.Descriptor instead.
+func (LevelRequirement) EnumDescriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{6}
+}
+
+// Race mirrors heroes.Race.
+type Race struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string      `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	BaseAttributes     *Attributes `protobuf:"bytes,4,opt,name=base_attributes,json=baseAttributes,proto3" json:"base_attributes,omitempty"`
+	StartingSkills     []*Skill    `protobuf:"bytes,5,rep,name=starting_skills,json=startingSkills,proto3" json:"starting_skills,omitempty"`
+	AvailableSkills    []*Skill    `protobuf:"bytes,6,rep,name=available_skills,json=availableSkills,proto3" json:"available_skills,omitempty"`
+	RecommendedClasses []*Class    `protobuf:"bytes,7,rep,name=recommended_classes,json=recommendedClasses,proto3" json:"recommended_classes,omitempty"`
+	Metadata           *Metadata   `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Slug               string      `protobuf:"bytes,9,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *Race) Reset() {
+	*x = Race{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Race) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Race) ProtoMessage() {}
+
+func (x *Race) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Race.ProtoReflect.Descriptor instead.
+func (*Race) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Race) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Race) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Race) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Race) GetBaseAttributes() *Attributes {
+	if x != nil {
+		return x.BaseAttributes
+	}
+	return nil
+}
+
+func (x *Race) GetStartingSkills() []*Skill {
+	if x != nil {
+		return x.StartingSkills
+	}
+	return nil
+}
+
+func (x *Race) GetAvailableSkills() []*Skill {
+	if x != nil {
+		return x.AvailableSkills
+	}
+	return nil
+}
+
+func (x *Race) GetRecommendedClasses() []*Class {
+	if x != nil {
+		return x.RecommendedClasses
+	}
+	return nil
+}
+
+func (x *Race) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Race) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// Class mirrors heroes.Class.
+type Class struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string         `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	BonusAttributes *Attributes    `protobuf:"bytes,4,opt,name=bonus_attributes,json=bonusAttributes,proto3" json:"bonus_attributes,omitempty"`
+	Role            Role           `protobuf:"varint,5,opt,name=role,proto3,enum=heroes.v1.Role" json:"role,omitempty"`
+	Proficiencies   []*Proficiency `protobuf:"bytes,6,rep,name=proficiencies,proto3" json:"proficiencies,omitempty"`
+	StartingSkills  []*Skill       `protobuf:"bytes,7,rep,name=starting_skills,json=startingSkills,proto3" json:"starting_skills,omitempty"`
+	AvailableSkills []*Skill       `protobuf:"bytes,8,rep,name=available_skills,json=availableSkills,proto3" json:"available_skills,omitempty"`
+	Metadata        *Metadata      `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Slug            string         `protobuf:"bytes,10,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *Class) Reset() {
+	*x = Class{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Class) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Class) ProtoMessage() {}
+
+func (x *Class) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Class.ProtoReflect.Descriptor instead.
+func (*Class) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Class) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Class) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Class) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Class) GetBonusAttributes() *Attributes {
+	if x != nil {
+		return x.BonusAttributes
+	}
+	return nil
+}
+
+func (x *Class) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Class) GetProficiencies() []*Proficiency {
+	if x != nil {
+		return x.Proficiencies
+	}
+	return nil
+}
+
+func (x *Class) GetStartingSkills() []*Skill {
+	if x != nil {
+		return x.StartingSkills
+	}
+	return nil
+}
+
+func (x *Class) GetAvailableSkills() []*Skill {
+	if x != nil {
+		return x.AvailableSkills
+	}
+	return nil
+}
+
+func (x *Class) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Class) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// Skill mirrors heroes.Skill.
+type Skill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                uint64           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string           `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Bonus             string           `protobuf:"bytes,4,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	Mana              string           `protobuf:"bytes,5,opt,name=mana,proto3" json:"mana,omitempty"`
+	DifficultyType    DifficultyType   `protobuf:"varint,6,opt,name=difficulty_type,json=difficultyType,proto3,enum=heroes.v1.DifficultyType" json:"difficulty_type,omitempty"`
+	Difficulty        string           `protobuf:"bytes,7,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Activation        Activation       `protobuf:"varint,8,opt,name=activation,proto3,enum=heroes.v1.Activation" json:"activation,omitempty"`
+	Source            Source           `protobuf:"varint,9,opt,name=source,proto3,enum=heroes.v1.Source" json:"source,omitempty"`
+	Type              SkillType        `protobuf:"varint,10,opt,name=type,proto3,enum=heroes.v1.SkillType" json:"type,omitempty"`
+	LevelRequirement  LevelRequirement `protobuf:"varint,11,opt,name=level_requirement,json=levelRequirement,proto3,enum=heroes.v1.LevelRequirement" json:"level_requirement,omitempty"`
+	SkillRequirements []*Skill         `protobuf:"bytes,12,rep,name=skill_requirements,json=skillRequirements,proto3" json:"skill_requirements,omitempty"`
+	Observations      string           `protobuf:"bytes,13,opt,name=observations,proto3" json:"observations,omitempty"`
+	Metadata          *Metadata        `protobuf:"bytes,14,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Slug              string           `protobuf:"bytes,15,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Skill) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Skill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Skill) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Skill) GetBonus() string {
+	if x != nil {
+		return x.Bonus
+	}
+	return ""
+}
+
+func (x *Skill) GetMana() string {
+	if x != nil {
+		return x.Mana
+	}
+	return ""
+}
+
+func (x *Skill) GetDifficultyType() DifficultyType {
+	if x != nil {
+		return x.DifficultyType
+	}
+	return DifficultyType_DIFFICULTY_TYPE_UNSPECIFIED
+}
+
+func (x *Skill) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Skill) GetActivation() Activation {
+	if x != nil {
+		return x.Activation
+	}
+	return Activation_ACTIVATION_UNSPECIFIED
+}
+
+func (x *Skill) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *Skill) GetType() SkillType {
+	if x != nil {
+		return x.Type
+	}
+	return SkillType_SKILL_TYPE_UNSPECIFIED
+}
+
+func (x *Skill) GetLevelRequirement() LevelRequirement {
+	if x != nil {
+		return x.LevelRequirement
+	}
+	return LevelRequirement_LEVEL_REQUIREMENT_UNSPECIFIED
+}
+
+func (x *Skill) GetSkillRequirements() []*Skill {
+	if x != nil {
+		return x.SkillRequirements
+	}
+	return nil
+}
+
+func (x *Skill) GetObservations() string {
+	if x != nil {
+		return x.Observations
+	}
+	return ""
+}
+
+func (x *Skill) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Skill) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// Proficiency mirrors heroes.Proficiency.
+type Proficiency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       uint64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     ProficiencyType `protobuf:"varint,2,opt,name=name,proto3,enum=heroes.v1.ProficiencyType" json:"name,omitempty"`
+	Metadata *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *Proficiency) Reset() {
+	*x = Proficiency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proficiency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proficiency) ProtoMessage() {}
+
+func (x *Proficiency) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proficiency.ProtoReflect.Descriptor instead.
+func (*Proficiency) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Proficiency) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Proficiency) GetName() ProficiencyType {
+	if x != nil {
+		return x.Name
+	}
+	return ProficiencyType_PROFICIENCY_TYPE_UNSPECIFIED
+}
+
+func (x *Proficiency) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Attributes mirrors heroes.Attribute.
+type Attributes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strength     int32 `protobuf:"varint,1,opt,name=strength,proto3" json:"strength,omitempty"`
+	Agility      int32 `protobuf:"varint,2,opt,name=agility,proto3" json:"agility,omitempty"`
+	Intelligence int32 `protobuf:"varint,3,opt,name=intelligence,proto3" json:"intelligence,omitempty"`
+	Willpower    int32 `protobuf:"varint,4,opt,name=willpower,proto3" json:"willpower,omitempty"`
+}
+
+func (x *Attributes) Reset() {
+	*x = Attributes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attributes) ProtoMessage() {}
+
+func (x *Attributes) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attributes.ProtoReflect.Descriptor instead.
+func (*Attributes) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Attributes) GetStrength() int32 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *Attributes) GetAgility() int32 {
+	if x != nil {
+		return x.Agility
+	}
+	return 0
+}
+
+func (x *Attributes) GetIntelligence() int32 {
+	if x != nil {
+		return x.Intelligence
+	}
+	return 0
+}
+
+func (x *Attributes) GetWillpower() int32 {
+	if x != nil {
+		return x.Willpower
+	}
+	return 0
+}
+
+// Metadata mirrors heroes.Timestamps and heroes.Revision.
+type Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Metadata) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Metadata) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Metadata) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListRacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRacesRequest) Reset() {
+	*x = ListRacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesRequest) ProtoMessage() {}
+
+func (x *ListRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesRequest.ProtoReflect.Descriptor instead.
+func (*ListRacesRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{6}
+}
+
+type ListRacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Races []*Race `protobuf:"bytes,1,rep,name=races,proto3" json:"races,omitempty"`
+}
+
+func (x *ListRacesResponse) Reset() {
+	*x = ListRacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesResponse) ProtoMessage() {}
+
+func (x *ListRacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesResponse.ProtoReflect.Descriptor instead.
+func (*ListRacesResponse) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRacesResponse) GetRaces() []*Race {
+	if x != nil {
+		return x.Races
+	}
+	return nil
+}
+
+type GetRaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRaceRequest) Reset() {
+	*x = GetRaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceRequest) ProtoMessage() {}
+
+func (x *GetRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceRequest.ProtoReflect.Descriptor instead.
+func (*GetRaceRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRaceRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListClassesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Role filters classes by role, unless unspecified.
+	Role Role `protobuf:"varint,1,opt,name=role,proto3,enum=heroes.v1.Role" json:"role,omitempty"`
+}
+
+func (x *ListClassesRequest) Reset() {
+	*x = ListClassesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListClassesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClassesRequest) ProtoMessage() {}
+
+func (x *ListClassesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClassesRequest.ProtoReflect.Descriptor instead.
+func (*ListClassesRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListClassesRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type ListClassesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Classes []*Class `protobuf:"bytes,1,rep,name=classes,proto3" json:"classes,omitempty"`
+}
+
+func (x *ListClassesResponse) Reset() {
+	*x = ListClassesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListClassesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClassesResponse) ProtoMessage() {}
+
+func (x *ListClassesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClassesResponse.ProtoReflect.Descriptor instead.
+func (*ListClassesResponse) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListClassesResponse) GetClasses() []*Class {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+type GetClassRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetClassRequest) Reset() {
+	*x = GetClassRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetClassRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClassRequest) ProtoMessage() {}
+
+func (x *GetClassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClassRequest.ProtoReflect.Descriptor instead.
+func (*GetClassRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetClassRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListSkillsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type filters skills by type, unless unspecified.
+	Type SkillType `protobuf:"varint,1,opt,name=type,proto3,enum=heroes.v1.SkillType" json:"type,omitempty"`
+	// Source filters skills by source, unless unspecified.
+	Source Source `protobuf:"varint,2,opt,name=source,proto3,enum=heroes.v1.Source" json:"source,omitempty"`
+}
+
+func (x *ListSkillsRequest) Reset() {
+	*x = ListSkillsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSkillsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSkillsRequest) ProtoMessage() {}
+
+func (x *ListSkillsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSkillsRequest.ProtoReflect.Descriptor instead.
+func (*ListSkillsRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSkillsRequest) GetType() SkillType {
+	if x != nil {
+		return x.Type
+	}
+	return SkillType_SKILL_TYPE_UNSPECIFIED
+}
+
+func (x *ListSkillsRequest) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+type ListSkillsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Skills []*Skill `protobuf:"bytes,1,rep,name=skills,proto3" json:"skills,omitempty"`
+}
+
+func (x *ListSkillsResponse) Reset() {
+	*x = ListSkillsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSkillsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSkillsResponse) ProtoMessage() {}
+
+func (x *ListSkillsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSkillsResponse.ProtoReflect.Descriptor instead.
+func (*ListSkillsResponse) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListSkillsResponse) GetSkills() []*Skill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+type GetSkillRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSkillRequest) Reset() {
+	*x = GetSkillRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSkillRequest) ProtoMessage() {}
+
+func (x *GetSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSkillRequest.ProtoReflect.Descriptor instead.
+func (*GetSkillRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSkillRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProficienciesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListProficienciesRequest) Reset() {
+	*x = ListProficienciesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProficienciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProficienciesRequest) ProtoMessage() {}
+
+func (x *ListProficienciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProficienciesRequest.ProtoReflect.Descriptor instead.
+func (*ListProficienciesRequest) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{15}
+}
+
+type ListProficienciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proficiencies []*Proficiency `protobuf:"bytes,1,rep,name=proficiencies,proto3" json:"proficiencies,omitempty"`
+}
+
+func (x *ListProficienciesResponse) Reset() {
+	*x = ListProficienciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_heroes_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProficienciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProficienciesResponse) ProtoMessage() {}
+
+func (x *ListProficienciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heroes_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProficienciesResponse.ProtoReflect.Descriptor instead.
+func (*ListProficienciesResponse) Descriptor() ([]byte, []int) {
+	return file_heroes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListProficienciesResponse) GetProficiencies() []*Proficiency {
+	if x != nil {
+		return x.Proficiencies
+	}
+	return nil
+}
+
+var File_heroes_proto protoreflect.FileDescriptor
+
+var file_heroes_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x03, 0x0a, 0x04, 0x52,
+	0x61, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0f, 0x62, 0x61, 0x73,
+	0x65, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x6b,
+	0x69, 0x6c, 0x6c, 0x73, 0x12, 0x3b, 0x0a, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c,
+	0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x6b, 0x69, 0x6c, 0x6c,
+	0x73, 0x12, 0x41, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x52, 0x12, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0xaf, 0x03, 0x0a, 0x05, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x10, 0x62, 0x6f, 0x6e,
+	0x75, 0x73, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x0f, 0x62, 0x6f, 0x6e, 0x75,
+	0x73, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x12, 0x3c, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x39,
+	0x0a, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x3b, 0x0a, 0x10, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0xdb, 0x04, 0x0a, 0x05,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6f, 0x6e, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x6e, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x6e, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6d, 0x61, 0x6e, 0x61, 0x12, 0x42, 0x0a, 0x0f, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75,
+	0x6c, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x69,
+	0x63, 0x75, 0x6c, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x64, 0x69, 0x66, 0x66, 0x69,
+	0x63, 0x75, 0x6c, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x66,
+	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x29, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x48, 0x0a, 0x11, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x10, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x3f, 0x0a, 0x12, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x65,
+	0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x11, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0x7e, 0x0a, 0x0b, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x68, 0x65, 0x72,
+	0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x67, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x67, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x22,
+	0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x6c, 0x6c, 0x69, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x6c, 0x6c, 0x69, 0x67, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x69, 0x6c, 0x6c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6c, 0x6c, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x22, 0x9a, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x12, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3a, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x52, 0x05, 0x72, 0x61, 0x63, 0x65, 0x73, 0x22, 0x20, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x39, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x41, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x52, 0x07, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x22, 0x21, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x68, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x29, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x3e, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69,
+	0x6c, 0x6c, 0x52, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1a, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x19, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x63,
+	0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63,
+	0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e,
+	0x63, 0x69, 0x65, 0x73, 0x2a, 0x58, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x10,
+	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x46, 0x49, 0x47, 0x48, 0x54,
+	0x45, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x50, 0x45,
+	0x4c, 0x4c, 0x43, 0x41, 0x53, 0x54, 0x45, 0x52, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x4f,
+	0x4c, 0x45, 0x5f, 0x44, 0x45, 0x58, 0x54, 0x45, 0x52, 0x4f, 0x55, 0x53, 0x10, 0x03, 0x2a, 0xe1,
+	0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x43,
+	0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x43, 0x49, 0x45,
+	0x4e, 0x43, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x49, 0x4d, 0x50, 0x4c, 0x45, 0x5f,
+	0x57, 0x45, 0x41, 0x50, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x50, 0x52, 0x4f,
+	0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x58, 0x5f, 0x57, 0x45, 0x41, 0x50, 0x4f, 0x4e, 0x53, 0x10, 0x02, 0x12,
+	0x1f, 0x0a, 0x1b, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x53, 0x54, 0x5f, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x10, 0x03,
+	0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x43, 0x59, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x4d, 0x41, 0x47, 0x49, 0x43, 0x10,
+	0x04, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x49, 0x43, 0x4b, 0x50, 0x4f, 0x43, 0x4b, 0x45, 0x54,
+	0x10, 0x05, 0x2a, 0xa5, 0x01, 0x0a, 0x0e, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74,
+	0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55,
+	0x4c, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43,
+	0x55, 0x4c, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x01,
+	0x12, 0x19, 0x0a, 0x15, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x58, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x44,
+	0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56,
+	0x41, 0x52, 0x49, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x49, 0x46,
+	0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41, 0x52,
+	0x47, 0x45, 0x54, 0x5f, 0x50, 0x4c, 0x55, 0x53, 0x10, 0x04, 0x2a, 0x70, 0x0a, 0x0a, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x43, 0x54, 0x49,
+	0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x41,
+	0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x41, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x49, 0x56, 0x45, 0x10, 0x03, 0x2a, 0x69, 0x0a, 0x06,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x42, 0x41, 0x53, 0x45, 0x10, 0x01, 0x12,
+	0x0f, 0x0a, 0x0b, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x41, 0x43, 0x45, 0x10, 0x02,
+	0x12, 0x10, 0x0a, 0x0c, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53,
+	0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x4e, 0x43,
+	0x45, 0x53, 0x54, 0x4f, 0x52, 0x10, 0x04, 0x2a, 0x8e, 0x01, 0x0a, 0x09, 0x53, 0x6b, 0x69, 0x6c,
+	0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x4b, 0x49,
+	0x4c, 0x4c, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x41, 0x43, 0x54, 0x45,
+	0x52, 0x49, 0x53, 0x54, 0x49, 0x43, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x4b, 0x49, 0x4c,
+	0x4c, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x45, 0x43, 0x48, 0x4e, 0x49, 0x51, 0x55, 0x45,
+	0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x53, 0x50, 0x45, 0x4c, 0x4c, 0x10, 0x04, 0x2a, 0xae, 0x01, 0x0a, 0x10, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a,
+	0x1d, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45,
+	0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e,
+	0x54, 0x5f, 0x41, 0x44, 0x56, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e,
+	0x54, 0x5f, 0x4d, 0x41, 0x53, 0x54, 0x45, 0x52, 0x10, 0x03, 0x12, 0x1d, 0x0a, 0x19, 0x4c, 0x45,
+	0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f,
+	0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x04, 0x32, 0xfb, 0x03, 0x0a, 0x0d, 0x48, 0x65,
+	0x72, 0x6f, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x61, 0x63, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52, 0x61, 0x63, 0x65, 0x12, 0x19,
+	0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x49, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73,
+	0x12, 0x1c, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x1a, 0x2e, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x5e, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x68,
+	0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4b, 0x5a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x67, 0x6c, 0x2d, 0x64, 0x6f, 0x67, 0x67, 0x2f, 0x67,
+	0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2d, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2d, 0x70, 0x6c, 0x61, 0x79, 0x2f, 0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x2d, 0x6d,
+	0x69, 0x63, 0x72, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x68, 0x65, 0x72, 0x6f,
+	0x65, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_heroes_proto_rawDescOnce sync.Once
+	file_heroes_proto_rawDescData = file_heroes_proto_rawDesc
+)
+
+func file_heroes_proto_rawDescGZIP() []byte {
+	file_heroes_proto_rawDescOnce.Do(func() {
+		file_heroes_proto_rawDescData = protoimpl.X.CompressGZIP(file_heroes_proto_rawDescData)
+	})
+	return file_heroes_proto_rawDescData
+}
+
+var file_heroes_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_heroes_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_heroes_proto_goTypes = []interface{}{
+	(Role)(0),                         // 0: heroes.v1.Role
+	(ProficiencyType)(0),              // 1: heroes.v1.ProficiencyType
+	(DifficultyType)(0),               // 2: heroes.v1.DifficultyType
+	(Activation)(0),                   // 3: heroes.v1.Activation
+	(Source)(0),                       // 4: heroes.v1.Source
+	(SkillType)(0),                    // 5: heroes.v1.SkillType
+	(LevelRequirement)(0),             // 6: heroes.v1.LevelRequirement
+	(*Race)(nil),                      // 7: heroes.v1.Race
+	(*Class)(nil),                     // 8: heroes.v1.Class
+	(*Skill)(nil),                     // 9: heroes.v1.Skill
+	(*Proficiency)(nil),               // 10: heroes.v1.Proficiency
+	(*Attributes)(nil),                // 11: heroes.v1.Attributes
+	(*Metadata)(nil),                  // 12: heroes.v1.Metadata
+	(*ListRacesRequest)(nil),          // 13: heroes.v1.ListRacesRequest
+	(*ListRacesResponse)(nil),         // 14: heroes.v1.ListRacesResponse
+	(*GetRaceRequest)(nil),            // 15: heroes.v1.GetRaceRequest
+	(*ListClassesRequest)(nil),        // 16: heroes.v1.ListClassesRequest
+	(*ListClassesResponse)(nil),       // 17: heroes.v1.ListClassesResponse
+	(*GetClassRequest)(nil),           // 18: heroes.v1.GetClassRequest
+	(*ListSkillsRequest)(nil),         // 19: heroes.v1.ListSkillsRequest
+	(*ListSkillsResponse)(nil),        // 20: heroes.v1.ListSkillsResponse
+	(*GetSkillRequest)(nil),           // 21: heroes.v1.GetSkillRequest
+	(*ListProficienciesRequest)(nil),  // 22: heroes.v1.ListProficienciesRequest
+	(*ListProficienciesResponse)(nil), // 23: heroes.v1.ListProficienciesResponse
+	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
+}
+var file_heroes_proto_depIdxs = []int32{
+	11, // 0: heroes.v1.Race.base_attributes:type_name -> heroes.v1.Attributes
+	9,  // 1: heroes.v1.Race.starting_skills:type_name -> heroes.v1.Skill
+	9,  // 2: heroes.v1.Race.available_skills:type_name -> heroes.v1.Skill
+	8,  // 3: heroes.v1.Race.recommended_classes:type_name -> heroes.v1.Class
+	12, // 4: heroes.v1.Race.metadata:type_name -> heroes.v1.Metadata
+	11, // 5: heroes.v1.Class.bonus_attributes:type_name -> heroes.v1.Attributes
+	0,  // 6: heroes.v1.Class.role:type_name -> heroes.v1.Role
+	10, // 7: heroes.v1.Class.proficiencies:type_name -> heroes.v1.Proficiency
+	9,  // 8: heroes.v1.Class.starting_skills:type_name -> heroes.v1.Skill
+	9,  // 9: heroes.v1.Class.available_skills:type_name -> heroes.v1.Skill
+	12, // 10: heroes.v1.Class.metadata:type_name -> heroes.v1.Metadata
+	2,  // 11: heroes.v1.Skill.difficulty_type:type_name -> heroes.v1.DifficultyType
+	3,  // 12: heroes.v1.Skill.activation:type_name -> heroes.v1.Activation
+	4,  // 13: heroes.v1.Skill.source:type_name -> heroes.v1.Source
+	5,  // 14: heroes.v1.Skill.type:type_name -> heroes.v1.SkillType
+	6,  // 15: heroes.v1.Skill.level_requirement:type_name -> heroes.v1.LevelRequirement
+	9,  // 16: heroes.v1.Skill.skill_requirements:type_name -> heroes.v1.Skill
+	12, // 17: heroes.v1.Skill.metadata:type_name -> heroes.v1.Metadata
+	1,  // 18: heroes.v1.Proficiency.name:type_name -> heroes.v1.ProficiencyType
+	12, // 19: heroes.v1.Proficiency.metadata:type_name -> heroes.v1.Metadata
+	24, // 20: heroes.v1.Metadata.created_at:type_name -> google.protobuf.Timestamp
+	24, // 21: heroes.v1.Metadata.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 22: heroes.v1.ListRacesResponse.races:type_name -> heroes.v1.Race
+	0,  // 23: heroes.v1.ListClassesRequest.role:type_name -> heroes.v1.Role
+	8,  // 24: heroes.v1.ListClassesResponse.classes:type_name -> heroes.v1.Class
+	5,  // 25: heroes.v1.ListSkillsRequest.type:type_name -> heroes.v1.SkillType
+	4,  // 26: heroes.v1.ListSkillsRequest.source:type_name -> heroes.v1.Source
+	9,  // 27: heroes.v1.ListSkillsResponse.skills:type_name -> heroes.v1.Skill
+	10, // 28: heroes.v1.ListProficienciesResponse.proficiencies:type_name -> heroes.v1.Proficiency
+	13, // 29: heroes.v1.HeroesService.ListRaces:input_type -> heroes.v1.ListRacesRequest
+	15, // 30: heroes.v1.HeroesService.GetRace:input_type -> heroes.v1.GetRaceRequest
+	16, // 31: heroes.v1.HeroesService.ListClasses:input_type -> heroes.v1.ListClassesRequest
+	18, // 32: heroes.v1.HeroesService.GetClass:input_type -> heroes.v1.GetClassRequest
+	19, // 33: heroes.v1.HeroesService.ListSkills:input_type -> heroes.v1.ListSkillsRequest
+	21, // 34: heroes.v1.HeroesService.GetSkill:input_type -> heroes.v1.GetSkillRequest
+	22, // 35: heroes.v1.HeroesService.ListProficiencies:input_type -> heroes.v1.ListProficienciesRequest
+	14, // 36: heroes.v1.HeroesService.ListRaces:output_type -> heroes.v1.ListRacesResponse
+	7,  // 37: heroes.v1.HeroesService.GetRace:output_type -> heroes.v1.Race
+	17, // 38: heroes.v1.HeroesService.ListClasses:output_type -> heroes.v1.ListClassesResponse
+	8,  // 39: heroes.v1.HeroesService.GetClass:output_type -> heroes.v1.Class
+	20, // 40: heroes.v1.HeroesService.ListSkills:output_type -> heroes.v1.ListSkillsResponse
+	9,  // 41: heroes.v1.HeroesService.GetSkill:output_type -> heroes.v1.Skill
+	23, // 42: heroes.v1.HeroesService.ListProficiencies:output_type -> heroes.v1.ListProficienciesResponse
+	36, // [36:43] is the sub-list for method output_type
+	29, // [29:36] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_heroes_proto_init() }
+func file_heroes_proto_init() {
+	if File_heroes_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_heroes_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Race); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Class); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Skill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proficiency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attributes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListClassesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListClassesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetClassRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSkillsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSkillsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSkillRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListProficienciesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_heroes_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListProficienciesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_heroes_proto_rawDesc,
+			NumEnums:      7,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heroes_proto_goTypes,
+		DependencyIndexes: file_heroes_proto_depIdxs,
+		EnumInfos:         file_heroes_proto_enumTypes,
+		MessageInfos:      file_heroes_proto_msgTypes,
+	}.Build()
+	File_heroes_proto = out.File
+	file_heroes_proto_rawDesc = nil
+	file_heroes_proto_goTypes = nil
+	file_heroes_proto_depIdxs = nil
+}
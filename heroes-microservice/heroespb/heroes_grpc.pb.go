@@ -0,0 +1,335 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: heroes.proto
+
+package heroespb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HeroesServiceClient is the client API for HeroesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HeroesServiceClient interface {
+	// ListRaces returns every race, without associations.
+	ListRaces(ctx context.Context, in *ListRacesRequest, opts ...grpc.CallOption) (*ListRacesResponse, error)
+	// GetRace returns a race along with its skills and recommended classes.
+	GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*Race, error)
+	// ListClasses returns every class, without associations. Can be filtered by role.
+	ListClasses(ctx context.Context, in *ListClassesRequest, opts ...grpc.CallOption) (*ListClassesResponse, error)
+	// GetClass returns a class along with its proficiencies and skills.
+	GetClass(ctx context.Context, in *GetClassRequest, opts ...grpc.CallOption) (*Class, error)
+	// ListSkills returns every skill, without requirements. Can be filtered by type and source.
+	ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (*ListSkillsResponse, error)
+	// GetSkill returns a skill along with its requirements.
+	GetSkill(ctx context.Context, in *GetSkillRequest, opts ...grpc.CallOption) (*Skill, error)
+	// ListProficiencies returns every proficiency.
+	ListProficiencies(ctx context.Context, in *ListProficienciesRequest, opts ...grpc.CallOption) (*ListProficienciesResponse, error)
+}
+
+type heroesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHeroesServiceClient(cc grpc.ClientConnInterface) HeroesServiceClient {
+	return &heroesServiceClient{cc}
+}
+
+func (c *heroesServiceClient) ListRaces(ctx context.Context, in *ListRacesRequest, opts ...grpc.CallOption) (*ListRacesResponse, error) {
+	out := new(ListRacesResponse)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/ListRaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*Race, error) {
+	out := new(Race)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/GetRace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) ListClasses(ctx context.Context, in *ListClassesRequest, opts ...grpc.CallOption) (*ListClassesResponse, error) {
+	out := new(ListClassesResponse)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/ListClasses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) GetClass(ctx context.Context, in *GetClassRequest, opts ...grpc.CallOption) (*Class, error) {
+	out := new(Class)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/GetClass", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (*ListSkillsResponse, error) {
+	out := new(ListSkillsResponse)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/ListSkills", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) GetSkill(ctx context.Context, in *GetSkillRequest, opts ...grpc.CallOption) (*Skill, error) {
+	out := new(Skill)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/GetSkill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heroesServiceClient) ListProficiencies(ctx context.Context, in *ListProficienciesRequest, opts ...grpc.CallOption) (*ListProficienciesResponse, error) {
+	out := new(ListProficienciesResponse)
+	err := c.cc.Invoke(ctx, "/heroes.v1.HeroesService/ListProficiencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HeroesServiceServer is the server API for HeroesService service.
+// All implementations must embed UnimplementedHeroesServiceServer
+// for forward compatibility
+type HeroesServiceServer interface {
+	// ListRaces returns every race, without associations.
+	ListRaces(context.Context, *ListRacesRequest) (*ListRacesResponse, error)
+	// GetRace returns a race along with its skills and recommended classes.
+	GetRace(context.Context, *GetRaceRequest) (*Race, error)
+	// ListClasses returns every class, without associations. Can be filtered by role.
+	ListClasses(context.Context, *ListClassesRequest) (*ListClassesResponse, error)
+	// GetClass returns a class along with its proficiencies and skills.
+	GetClass(context.Context, *GetClassRequest) (*Class, error)
+	// ListSkills returns every skill, without requirements. Can be filtered by type and source.
+	ListSkills(context.Context, *ListSkillsRequest) (*ListSkillsResponse, error)
+	// GetSkill returns a skill along with its requirements.
+	GetSkill(context.Context, *GetSkillRequest) (*Skill, error)
+	// ListProficiencies returns every proficiency.
+	ListProficiencies(context.Context, *ListProficienciesRequest) (*ListProficienciesResponse, error)
+	mustEmbedUnimplementedHeroesServiceServer()
+}
+
+// UnimplementedHeroesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHeroesServiceServer struct {
+}
+
+func (UnimplementedHeroesServiceServer) ListRaces(context.Context, *ListRacesRequest) (*ListRacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRaces not implemented")
+}
+func (UnimplementedHeroesServiceServer) GetRace(context.Context, *GetRaceRequest) (*Race, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRace not implemented")
+}
+func (UnimplementedHeroesServiceServer) ListClasses(context.Context, *ListClassesRequest) (*ListClassesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClasses not implemented")
+}
+func (UnimplementedHeroesServiceServer) GetClass(context.Context, *GetClassRequest) (*Class, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClass not implemented")
+}
+func (UnimplementedHeroesServiceServer) ListSkills(context.Context, *ListSkillsRequest) (*ListSkillsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSkills not implemented")
+}
+func (UnimplementedHeroesServiceServer) GetSkill(context.Context, *GetSkillRequest) (*Skill, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSkill not implemented")
+}
+func (UnimplementedHeroesServiceServer) ListProficiencies(context.Context, *ListProficienciesRequest) (*ListProficienciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProficiencies not implemented")
+}
+func (UnimplementedHeroesServiceServer) mustEmbedUnimplementedHeroesServiceServer() {}
+
+// UnsafeHeroesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HeroesServiceServer will
+// result in compilation errors.
+type UnsafeHeroesServiceServer interface {
+	mustEmbedUnimplementedHeroesServiceServer()
+}
+
+func RegisterHeroesServiceServer(s grpc.ServiceRegistrar, srv HeroesServiceServer) {
+	s.RegisterService(&HeroesService_ServiceDesc, srv)
+}
+
+func _HeroesService_ListRaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).ListRaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/ListRaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).ListRaces(ctx, req.(*ListRacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_GetRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).GetRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/GetRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).GetRace(ctx, req.(*GetRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_ListClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClassesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).ListClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/ListClasses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).ListClasses(ctx, req.(*ListClassesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_GetClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).GetClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/GetClass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).GetClass(ctx, req.(*GetClassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_ListSkills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSkillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).ListSkills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/ListSkills",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).ListSkills(ctx, req.(*ListSkillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_GetSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).GetSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/GetSkill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).GetSkill(ctx, req.(*GetSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeroesService_ListProficiencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProficienciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeroesServiceServer).ListProficiencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/heroes.v1.HeroesService/ListProficiencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeroesServiceServer).ListProficiencies(ctx, req.(*ListProficienciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HeroesService_ServiceDesc is the grpc.ServiceDesc for HeroesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HeroesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "heroes.v1.HeroesService",
+	HandlerType: (*HeroesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRaces",
+			Handler:    _HeroesService_ListRaces_Handler,
+		},
+		{
+			MethodName: "GetRace",
+			Handler:    _HeroesService_GetRace_Handler,
+		},
+		{
+			MethodName: "ListClasses",
+			Handler:    _HeroesService_ListClasses_Handler,
+		},
+		{
+			MethodName: "GetClass",
+			Handler:    _HeroesService_GetClass_Handler,
+		},
+		{
+			MethodName: "ListSkills",
+			Handler:    _HeroesService_ListSkills_Handler,
+		},
+		{
+			MethodName: "GetSkill",
+			Handler:    _HeroesService_GetSkill_Handler,
+		},
+		{
+			MethodName: "ListProficiencies",
+			Handler:    _HeroesService_ListProficiencies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "heroes.proto",
+}
@@ -1,14 +1,27 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/controllers"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/openapi"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/outbox"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/rpc"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/search"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/webhooks"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -16,9 +29,16 @@ func main() {
 
 	repository := setupDatabase()
 	runMigrations(repository)
+	feed := setupHooks(repository)
+	setupOutbox(repository)
+	dispatcher := setupWebhooks(repository, feed)
+
+	searcher := setupSearch(repository)
+
+	setupGRPC(repository)
 
 	router := gin.Default()
-	setupRoutes(router, repository)
+	setupRoutes(router, repository, feed, dispatcher, searcher)
 	router.Run("localhost:8080")
 }
 
@@ -39,32 +59,307 @@ func setupDatabase() database.Repository {
 	}
 	dbConnection.Setup()
 
-	return database.NewRepository(database.GetDB())
+	return setupCache(database.NewRepository(database.GetDB()))
+}
+
+// setupCache optionally wraps the repository with a read-through cache when CACHE_TTL is set (e.g. "5m").
+// A CACHE_TTL of 0 disables the cache, the same as leaving it unset.
+func setupCache(repository database.Repository) database.Repository {
+	ttl := os.Getenv("CACHE_TTL")
+	if ttl == "" {
+		return repository
+	}
+
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration < 0 {
+		log.Panicf("Invalid CACHE_TTL value: %s. Should be a positive duration, or 0 to disable the cache. Err: %v", ttl, err)
+	}
+
+	if duration == 0 {
+		return repository
+	}
+
+	maxEntries := 0
+	if size := os.Getenv("CACHE_MAX_ENTRIES"); size != "" {
+		if maxEntries, err = strconv.Atoi(size); err != nil {
+			log.Panicf("Invalid CACHE_MAX_ENTRIES value: %s. Err: %s", size, err)
+		}
+	}
+
+	return database.NewCachedRepository(repository, duration, maxEntries)
 }
 
 func runMigrations(repository database.Repository) {
 	if os.Getenv("RUN_MIGRATIONS") == "true" {
+		// unaccent makes full text search accent insensitive, see search.SQLSearcher.
+		if err := repository.GetDB().Exec("CREATE EXTENSION IF NOT EXISTS unaccent").Error; err != nil {
+			log.Printf("Some error occurred while creating the unaccent extension. Err: %s", err)
+		}
+
+		repository.GetDB().AutoMigrate([]heroes.Proficiency{})
 		repository.GetDB().AutoMigrate([]heroes.Skill{})
 		repository.GetDB().AutoMigrate([]heroes.Class{})
 		repository.GetDB().AutoMigrate([]heroes.Race{})
+		repository.GetDB().AutoMigrate([]database.AuditEntry{})
+		repository.GetDB().AutoMigrate([]events.Event{})
+		repository.GetDB().AutoMigrate([]webhooks.Subscription{})
+		repository.GetDB().AutoMigrate([]webhooks.Delivery{})
+		repository.GetDB().AutoMigrate([]outbox.Message{})
+		backfillSlugs(repository)
+		seedProficiencies(repository)
+	}
+}
+
+// backfillSlugs gives a slug to every race, class and skill stored before slugs existed.
+func backfillSlugs(repository database.Repository) {
+	for _, model := range []interface{}{&heroes.Race{}, &heroes.Class{}, &heroes.Skill{}} {
+		if err := database.BackfillSlugs(repository.GetDB(), model); err != nil {
+			log.Printf("Some error occurred while backfilling slugs of %T. Err: %s", model, err)
+		}
 	}
 }
 
-func setupRoutes(router *gin.Engine, repository database.Repository) {
+// seedProficiencies creates every proficiency defined by heroes.Enums that isn't stored yet.
+func seedProficiencies(repository database.Repository) {
+	for _, name := range heroes.Values[heroes.ProficiencyType]() {
+		proficiency := heroes.Proficiency{Name: name}
+		if err := repository.GetDB().Where("name = ?", proficiency.Name).FirstOrCreate(&proficiency).Error; err != nil {
+			log.Printf("Some error occurred while seeding proficiency %s. Err: %s", name, err)
+		}
+	}
+}
+
+// setupSearch picks the search backend from SEARCH_BACKEND: "sql" (default) for Postgres full text search, or "memory"
+// for an in-process index loaded on startup and kept up to date with every write.
+func setupSearch(repository database.Repository) search.Searcher {
+	switch backend := os.Getenv("SEARCH_BACKEND"); backend {
+	case "", "sql":
+		return search.NewSQLSearcher(repository.GetDB())
+	case "memory":
+		index := search.NewIndex()
+		if err := index.Load(repository); err != nil {
+			log.Panicf("Some error occurred while loading the search index. Err: %s", err)
+		}
+
+		repository.OnCommit(index.Listener(repository))
+		return index
+	default:
+		log.Panicf("Invalid SEARCH_BACKEND value: %s. Should be sql or memory.", backend)
+		return nil
+	}
+}
+
+// setupHooks records audit entries and change events along with every write, then starts feeding events to subscribers.
+// EVENTS_POLL_INTERVAL (e.g. "2s") sets how often events written by other instances are picked up.
+func setupHooks(repository database.Repository) *events.Feed {
+	repository.OnChange(database.AuditHook)
+	repository.OnChange(events.Hook)
+
+	feed, err := events.NewFeed(repository.GetDB())
+	if err != nil {
+		log.Panicf("Some error occurred while setting up the event feed. Err: %s", err)
+	}
+	repository.OnCommit(feed.Notify)
+
+	interval := 2 * time.Second
+	if value := os.Getenv("EVENTS_POLL_INTERVAL"); value != "" {
+		if interval, err = time.ParseDuration(value); err != nil {
+			log.Panicf("Invalid EVENTS_POLL_INTERVAL value: %s. Err: %s", value, err)
+		}
+	}
+
+	go feed.Run(interval, nil)
+	return feed
+}
+
+// setupOutbox writes a domain event to the outbox along with every write and starts relaying them to the broker
+// selected by BROKER: "nats" (NATS_URL), "kafka" (comma separated KAFKA_BROKERS) or "memory". Nothing is written to the
+// outbox when BROKER is not set. OUTBOX_POLL_INTERVAL (e.g. "5s") sets how often failed or leftover messages are retried.
+func setupOutbox(repository database.Repository) *outbox.Relay {
+	broker, err := newBroker(os.Getenv("BROKER"))
+	if err != nil {
+		log.Panicf("Some error occurred while connecting to the message broker. Err: %s", err)
+	}
+
+	if broker == nil {
+		return nil
+	}
+
+	interval := 5 * time.Second
+	if value := os.Getenv("OUTBOX_POLL_INTERVAL"); value != "" {
+		if interval, err = time.ParseDuration(value); err != nil {
+			log.Panicf("Invalid OUTBOX_POLL_INTERVAL value: %s. Err: %s", value, err)
+		}
+	}
+
+	relay := outbox.NewRelay(repository.GetDB(), broker)
+	repository.OnChange(outbox.Hook)
+	repository.OnCommit(relay.Notify)
+
+	go relay.Run(interval, nil)
+	return relay
+}
+
+func newBroker(kind string) (outbox.Broker, error) {
+	switch kind {
+	case "":
+		return nil, nil
+	case "memory":
+		return outbox.NewMemoryBroker(), nil
+	case "nats":
+		return outbox.NewNATSBroker(os.Getenv("NATS_URL"))
+	case "kafka":
+		return outbox.NewKafkaBroker(strings.Split(os.Getenv("KAFKA_BROKERS"), ",")...), nil
+	default:
+		return nil, fmt.Errorf("unknown broker %q", kind)
+	}
+}
+
+// setupWebhooks starts delivering change events to webhook subscriptions.
+func setupWebhooks(repository database.Repository, feed *events.Feed) *webhooks.Dispatcher {
+	dispatcher := webhooks.NewDispatcher(repository.GetDB(), &http.Client{Timeout: 10 * time.Second})
+	go dispatcher.Run(feed, 5*time.Second, nil)
+
+	return dispatcher
+}
+
+// setupGRPC serves the gRPC API on its own port, set by GRPC_ADDRESS (defaults to "localhost:9090").
+func setupGRPC(repository database.Repository) *grpc.Server {
+	address := os.Getenv("GRPC_ADDRESS")
+	if address == "" {
+		address = "localhost:9090"
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Panicf("Some error occurred while listening for gRPC on %s. Err: %s", address, err)
+	}
+
+	server := rpc.Register(repository)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Println("Error while serving gRPC: ", err)
+		}
+	}()
+
+	return server
+}
+
+func setupRoutes(router *gin.Engine, repository database.Repository, feed *events.Feed, dispatcher *webhooks.Dispatcher, searcher search.Searcher) {
+	router.Use(controllers.Authenticate(adminTokens()))
+
 	race := controllers.NewRaceHandler(repository)
 	router.GET("/races", race.GetAll)
 	router.GET("/races/:id", race.GetByID)
+	router.GET("/races/by-name/:name", race.GetByName)
+	router.POST("/races/batch", race.GetBatch)
 	router.GET("/races/by-recommended-classes", race.GetByRecommendedClasses)
+	router.POST("/races", controllers.RequireAdmin, race.Create)
+	router.PUT("/races/:id", controllers.RequireAdmin, race.Update)
+	router.PATCH("/races/:id", controllers.RequireAdmin, race.Patch)
+	router.DELETE("/races/:id", controllers.RequireAdmin, race.Delete)
+	router.POST("/races/:id/restore", controllers.RequireAdmin, race.Restore)
+	router.GET("/races/:id/history", controllers.RequireAdmin, race.GetHistory)
 
 	class := controllers.NewClassHandler(repository)
 	router.GET("/classes", class.GetAll)
 	router.GET("/classes/:id", class.GetByID)
+	router.GET("/classes/by-name/:name", class.GetByName)
+	router.POST("/classes/batch", class.GetBatch)
 	router.GET("/classes/by-role/:role", class.GetByRole)
 	router.GET("/classes/by-proficiencies", class.GetByProficiencies)
+	router.POST("/classes", controllers.RequireAdmin, class.Create)
+	router.PUT("/classes/:id", controllers.RequireAdmin, class.Update)
+	router.PATCH("/classes/:id", controllers.RequireAdmin, class.Patch)
+	router.DELETE("/classes/:id", controllers.RequireAdmin, class.Delete)
+	router.POST("/classes/:id/restore", controllers.RequireAdmin, class.Restore)
+	router.GET("/classes/:id/history", controllers.RequireAdmin, class.GetHistory)
 
 	skill := controllers.NewSkillHandler(repository)
 	router.GET("/skills", skill.GetAll)
 	router.GET("/skills/:id", skill.GetByID)
+	router.GET("/skills/by-name/:name", skill.GetByName)
+	router.POST("/skills/batch", skill.GetBatch)
 	router.GET("/skills/by-type/:type", skill.GetByType)
 	router.GET("/skills/by-source/:source", skill.GetBySource)
+	router.POST("/skills", controllers.RequireAdmin, skill.Create)
+	router.PUT("/skills/:id", controllers.RequireAdmin, skill.Update)
+	router.PATCH("/skills/:id", controllers.RequireAdmin, skill.Patch)
+	router.DELETE("/skills/:id", controllers.RequireAdmin, skill.Delete)
+	router.POST("/skills/:id/restore", controllers.RequireAdmin, skill.Restore)
+	router.GET("/skills/:id/history", controllers.RequireAdmin, skill.GetHistory)
+	router.POST("/skills/:id/check", skill.Check)
+
+	proficiency := controllers.NewProficiencyHandler(repository)
+	router.GET("/proficiencies", proficiency.GetAll)
+	router.GET("/proficiencies/:id", proficiency.GetByID)
+	router.GET("/proficiencies/:id/classes", proficiency.GetClasses)
+
+	compendium := controllers.NewCompendiumHandler(repository)
+	router.GET("/export", compendium.Export)
+	router.POST("/import", controllers.RequireAdmin, compendium.Import)
+
+	audit := controllers.NewAuditHandler(repository)
+	router.GET("/audit", controllers.RequireAdmin, audit.GetAll)
+
+	gql := controllers.NewGraphQLHandler(repository)
+	router.GET("/graphql", gql.Query)
+	router.POST("/graphql", gql.Query)
+
+	if searcher == nil {
+		searcher = search.NewSQLSearcher(repository.GetDB())
+	}
+	finder := controllers.NewSearchHandler(searcher)
+	router.GET("/search", finder.Search)
+
+	meta := controllers.NewMetaHandler()
+	router.GET("/meta/enums", meta.GetEnums)
+
+	roller := controllers.NewDiceHandler()
+	router.GET("/dice/roll", roller.Roll)
+
+	changes := controllers.NewEventsHandler(feed)
+	router.GET("/events", changes.Stream)
+
+	webhook := controllers.NewWebhookHandler(repository, dispatcher)
+	router.GET("/webhooks", controllers.RequireAdmin, webhook.GetAll)
+	router.POST("/webhooks", controllers.RequireAdmin, webhook.Create)
+	router.GET("/webhooks/:id", controllers.RequireAdmin, webhook.GetByID)
+	router.DELETE("/webhooks/:id", controllers.RequireAdmin, webhook.Delete)
+	router.GET("/webhooks/:id/deliveries", controllers.RequireAdmin, webhook.GetDeliveries)
+	router.GET("/webhooks/dead-letters", controllers.RequireAdmin, webhook.GetDeadLetters)
+	router.POST("/webhooks/dead-letters/:id/retry", controllers.RequireAdmin, webhook.Retry)
+
+	if cached, ok := repository.(*database.CachedRepository); ok {
+		cache := controllers.NewCacheHandler(cached)
+		router.GET("/cache/stats", controllers.RequireAdmin, cache.GetStats)
+	}
+
+	// The document describes every route, so it is only built once they are all registered.
+	document := &openapi.Document{}
+	spec := controllers.NewOpenAPIHandler(document)
+	router.GET("/openapi.json", spec.Get)
+
+	var undocumented []string
+	*document, undocumented = apiDocument(router.Routes())
+	for _, route := range undocumented {
+		log.Println("Route missing from the OpenAPI document: ", route)
+	}
+}
+
+// adminTokens maps administrator tokens to their owners. ADMIN_TOKEN belongs to "admin", while ADMIN_TOKENS holds
+// comma separated name:token pairs, so every game master can have their own changes audited.
+func adminTokens() map[string]string {
+	tokens := map[string]string{}
+	if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+		tokens[token] = "admin"
+	}
+
+	for _, pair := range strings.Split(os.Getenv("ADMIN_TOKENS"), ",") {
+		if name, token, found := strings.Cut(strings.TrimSpace(pair), ":"); found && token != "" {
+			tokens[token] = name
+		}
+	}
+
+	return tokens
 }
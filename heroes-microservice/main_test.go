@@ -1,22 +1,45 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/compendium"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/controllers"
 	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/graph"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/heroespb"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/openapi"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/outbox"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/rpc"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/search"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/webhooks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -24,6 +47,8 @@ import (
 var emptyRows = sqlmock.NewRows([]string{"id"})
 var errMock = errors.New("just a mock error")
 
+const adminToken = "mock-admin-token"
+
 func setup() (db *sql.DB, mock sqlmock.Sqlmock, repository database.Repository) {
 	// Open sqlmock connection.
 	db, mock, err := sqlmock.New()
@@ -85,8 +110,16 @@ func Test_RunMigrations_OK(t *testing.T) {
 
 	countZero := sqlmock.NewRows([]string{"count"}).AddRow(0)
 	successfulExec := sqlmock.NewResult(0, 0)
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS unaccent").WillReturnResult(successfulExec)
+
+	mock.ExpectQuery("SELECT count(.+)").WillReturnRows(countZero)
+	mock.ExpectExec("CREATE TABLE \"proficiencies\" (.+)").WillReturnResult(successfulExec)
+	mock.ExpectExec("CREATE INDEX (.+) ON \"proficiencies\" (.+)").WillReturnResult(successfulExec)
+
 	mock.ExpectQuery("SELECT count(.+)").WillReturnRows(countZero)
 	mock.ExpectExec("CREATE TABLE \"skills\" (.+)").WillReturnResult(successfulExec)
+	mock.ExpectExec("CREATE (UNIQUE )?INDEX (.+) ON \"skills\" (.+)").WillReturnResult(successfulExec)
+	mock.ExpectExec("CREATE (UNIQUE )?INDEX (.+) ON \"skills\" (.+)").WillReturnResult(successfulExec)
 	mock.ExpectExec("CREATE TABLE \"skill_requirements\" (.+)").WillReturnResult(successfulExec)
 
 	mock.ExpectQuery("SELECT count(.+)").WillReturnRows(countZero)
@@ -117,20 +150,50 @@ func Test_RoutesRegisted_OK(t *testing.T) {
 	defer db.Close()
 
 	r := gin.New()
-	setupRoutes(r, repository)
+	setupRoutes(r, repository, nil, nil, nil)
 
 	routesMap := map[string]bool{
-		"/races":                        false,
-		"/races/:id":                    false,
-		"/races/by-recommended-classes": false,
-		"/classes":                      false,
-		"/classes/:id":                  false,
-		"/classes/by-role/:role":        false,
-		"/classes/by-proficiencies":     false,
-		"/skills":                       false,
-		"/skills/:id":                   false,
-		"/skills/by-type/:type":         false,
-		"/skills/by-source/:source":     false,
+		"/races":                           false,
+		"/races/:id":                       false,
+		"/races/by-name/:name":             false,
+		"/races/batch":                     false,
+		"/races/by-recommended-classes":    false,
+		"/races/:id/restore":               false,
+		"/races/:id/history":               false,
+		"/classes":                         false,
+		"/classes/:id":                     false,
+		"/classes/by-name/:name":           false,
+		"/classes/batch":                   false,
+		"/classes/by-role/:role":           false,
+		"/classes/by-proficiencies":        false,
+		"/classes/:id/restore":             false,
+		"/classes/:id/history":             false,
+		"/skills":                          false,
+		"/skills/:id":                      false,
+		"/skills/by-name/:name":            false,
+		"/skills/batch":                    false,
+		"/skills/by-type/:type":            false,
+		"/skills/by-source/:source":        false,
+		"/skills/:id/restore":              false,
+		"/skills/:id/history":              false,
+		"/skills/:id/check":                false,
+		"/proficiencies":                   false,
+		"/proficiencies/:id":               false,
+		"/proficiencies/:id/classes":       false,
+		"/export":                          false,
+		"/import":                          false,
+		"/audit":                           false,
+		"/graphql":                         false,
+		"/openapi.json":                    false,
+		"/meta/enums":                      false,
+		"/search":                          false,
+		"/dice/roll":                       false,
+		"/events":                          false,
+		"/webhooks":                        false,
+		"/webhooks/:id":                    false,
+		"/webhooks/:id/deliveries":         false,
+		"/webhooks/dead-letters":           false,
+		"/webhooks/dead-letters/:id/retry": false,
 	}
 
 	for _, v := range r.Routes() {
@@ -172,6 +235,56 @@ func Test_GetRaces_OK(t *testing.T) {
 	shutdown(mock)
 }
 
+func Test_GetRaces_PAGINATE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(5, "Goblin").AddRow(6, "Orc")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE (.+) ORDER BY \"races\".\"id\" LIMIT 2 OFFSET 4").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/?limit=2&offset=4&filter=name%20ne%20Elf", http.StatusOK)
+
+	var races []heroes.Race
+	decodeJSON(resp.Body, &races)
+
+	if len(races) != 2 {
+		t.Error("Invalid records found:", races)
+	}
+
+	emulateRequest(r, "/?limit=0", http.StatusBadRequest)
+	emulateRequest(r, "/?offset=-1", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_GetRaces_EXPAND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "Elf"))
+	mock.ExpectQuery("SELECT (.+) FROM \"race_recommended_classes\" WHERE \"race_recommended_classes\".\"race_id\" = \\$1").WithArgs(1).WillReturnRows(mock.NewRows([]string{"race_id", "class_id"}).AddRow(1, 2))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = \\$1").WithArgs(2).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(2, "Wizard"))
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" WHERE \"class_proficiencies\".\"class_id\" = \\$1").WithArgs(2).WillReturnRows(mock.NewRows([]string{"class_id", "proficiency_id"}).AddRow(2, 3))
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\" WHERE \"proficiencies\".\"id\" = \\$1").WithArgs(3).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(3, "cast_magic"))
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/?expand=recommended_classes.proficiencies", http.StatusOK)
+
+	var races []heroes.Race
+	decodeJSON(resp.Body, &races)
+
+	if len(races) != 1 || len(races[0].RecommendedClasses) != 1 || len(races[0].RecommendedClasses[0].Proficiencies) != 1 {
+		t.Error("Invalid records found:", races)
+	}
+
+	shutdown(mock)
+}
+
 func Test_GetRaces_NOK(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
@@ -212,19 +325,21 @@ func Test_GetRaceByID_OK(t *testing.T) {
 	shutdown(mock)
 }
 
-func Test_GetRaceByID_INVALID(t *testing.T) {
+func Test_GetRaceBySlug_NOTFOUND(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
 	h := controllers.NewRaceHandler(repository)
 
-	invalidID := "98a11010-d019-11ec-9d64-0242ac120002"
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"slug\" = \\$1 AND \"races\".\"deleted_at\" IS NULL").WithArgs("elfo").WillReturnRows(emptyRows)
+	rows := mock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Elf", "elf").AddRow(2, "Half-Elf", "half-elf").AddRow(3, "Dwarf", "dwarf")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(rows)
 
 	r := gin.New()
 	r.GET("/:id", h.GetByID)
-	resp := emulateRequest(r, "/"+invalidID, http.StatusBadRequest)
+	resp := emulateRequest(r, "/Elfo", http.StatusNotFound)
 
 	body := resp.Body.String()
-	if !strings.Contains(body, invalidID) {
+	if !strings.Contains(body, "Did you mean Elf?") {
 		t.Error("Invalid response error:", body)
 	}
 
@@ -268,6 +383,29 @@ func Test_GetRaceByRecommendedClasses_OK(t *testing.T) {
 	shutdown(mock)
 }
 
+func Test_GetRaceByRecommendedClasses_MATCHNONE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(2, "Dwarf")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \\(races.id NOT IN \\(SELECT j.race_id FROM race_recommended_classes j INNER JOIN classes t (.+) WHERE t.deleted_at IS NULL AND (.+)\\)\\)").WithArgs("wizard").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"race_recommended_classes\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/mock", h.GetByRecommendedClasses)
+	resp := emulateRequest(r, "/mock?match=none&classes=Wizard", http.StatusOK)
+
+	var races []heroes.Race
+	decodeJSON(resp.Body, &races)
+
+	if len(races) != 1 || len(races[0].RecommendedClasses) != 0 {
+		t.Error("Invalid record found:", races)
+	}
+
+	shutdown(mock)
+}
+
 func Test_GetRaceByRecommendedClasses_NOK(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
@@ -344,18 +482,149 @@ func Test_GetClassByID_OK(t *testing.T) {
 	shutdown(mock)
 }
 
-func Test_GetClassByID_INVALID(t *testing.T) {
+func Test_GetClassBySlug_OK(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
 	ch := controllers.NewClassHandler(repository)
 
-	invalidID := "98a11010-d019-11ec-9d64-0242ac120002"
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"slug\" = (.+)").WithArgs("arcane-wizard").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = (.+)").WithArgs(2).WillReturnRows(mock.NewRows([]string{"id", "name", "slug"}).AddRow(2, "Arcane Wizard", "arcane-wizard"))
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
 	r := gin.New()
 	r.GET("/:id", ch.GetByID)
-	resp := emulateRequest(r, "/"+invalidID, http.StatusBadRequest)
+	resp := emulateRequest(r, "/Arcane_Wizard", http.StatusOK)
+
+	var class heroes.Class
+	decodeJSON(resp.Body, &class)
+
+	if class.ID != 2 || class.Slug != "arcane-wizard" {
+		t.Error("Invalid record found:", class)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassByID_FIELDS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "bonus_strength", "bonus_agility"}).AddRow(1, "Warrior", 2, 1)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = (.+)").WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(mock.NewRows([]string{"class_id", "skill_id"}).AddRow(1, 5))
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" (.+)").WillReturnRows(mock.NewRows([]string{"id", "name", "mana"}).AddRow(5, "Bash", "2"))
+
+	r := gin.New()
+	r.GET("/:id", ch.GetByID)
+	resp := emulateRequest(r, "/1?expand=starting_skills&fields=name,bonus_attributes.strength,starting_skills.name", http.StatusOK)
+
+	var class map[string]interface{}
+	decodeJSON(resp.Body, &class)
+
+	expected := map[string]interface{}{
+		"name":             "Warrior",
+		"bonus_attributes": map[string]interface{}{"strength": 2.0},
+		"starting_skills":  []interface{}{map[string]interface{}{"name": "Bash"}},
+	}
+	if !reflect.DeepEqual(class, expected) {
+		t.Error("Invalid fields found:", class)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClasses_FIELDS_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.GET("/", ch.GetAll)
+	for query, message := range map[string]string{
+		"expand=skills": "Class has no association skills. Should be one of available_skills, proficiencies, starting_skills",
+		"expand=starting_skills.skill_requirement.skill_requirement.skill_requirement": "deeper than 3 associations",
+		"expand=bonus_attributes": "Class has no association bonus_attributes",
+		"fields=name,power":       "Class has no field power",
+		"fields=name.first":       "name has no nested fields",
+	} {
+		resp := emulateRequest(r, "/?"+query, http.StatusBadRequest)
+		if body := resp.Body.String(); !strings.Contains(body, message) {
+			t.Errorf("Expected %s to fail with %s, got: %s", query, message, body)
+		}
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassByName_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \\(slug = (.+) OR LOWER\\(name\\) = (.+)\\) (.+) LIMIT 1").WithArgs("mago", "mago").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "Warrior").AddRow(2, "Mágo"))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = (.+)").WithArgs(2).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(2, "Mágo"))
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/by-name/:name", ch.GetByName)
+	resp := emulateRequest(r, "/by-name/MAGO", http.StatusOK)
+
+	var class heroes.Class
+	decodeJSON(resp.Body, &class)
+
+	if class.ID != 2 {
+		t.Error("Invalid record found:", class)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassByName_SLUG(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \\(slug = (.+) OR LOWER\\(name\\) = (.+)\\) (.+) LIMIT 1").WithArgs("dark-knight", "dark knight").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(5, "Dark Knight"))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = (.+)").WithArgs(5).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(5, "Dark Knight"))
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/by-name/:name", ch.GetByName)
+	resp := emulateRequest(r, "/by-name/Dark%20Knight", http.StatusOK)
+
+	var class heroes.Class
+	decodeJSON(resp.Body, &class)
+
+	if class.ID != 5 {
+		t.Error("Invalid record found:", class)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassByName_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(1, "Warrior").AddRow(2, "Wizard").AddRow(3, "Warlord").AddRow(4, "Rogue")
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE (.+) LIMIT 1").WithArgs("warior", "warior").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/by-name/:name", ch.GetByName)
+	resp := emulateRequest(r, "/by-name/warior", http.StatusNotFound)
 
 	body := resp.Body.String()
-	if !strings.Contains(body, invalidID) {
+	if !strings.Contains(body, "Did you mean Warrior or Warlord?") {
 		t.Error("Invalid response error:", body)
 	}
 
@@ -407,7 +676,26 @@ func Test_GetClassByRole_NOK(t *testing.T) {
 
 	r := gin.New()
 	r.GET("/:role", ch.GetByRole)
-	emulateRequest(r, "/malandro", http.StatusInternalServerError)
+	emulateRequest(r, "/spellcaster", http.StatusInternalServerError)
+
+	shutdown(mock)
+}
+
+func Test_GetClassByRole_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.GET("/:role", ch.GetByRole)
+	resp := emulateRequest(r, "/figther", http.StatusBadRequest)
+
+	var message string
+	decodeJSON(resp.Body, &message)
+
+	if !strings.Contains(message, "fighter, spellcaster, dexterous") {
+		t.Error("Expected allowed values in message:", message)
+	}
 
 	shutdown(mock)
 }
@@ -443,7 +731,95 @@ func Test_GetClassByProficiencies_NOK(t *testing.T) {
 
 	r := gin.New()
 	r.GET("/mock", ch.GetByProficiencies)
-	emulateRequest(r, "/mock?proficiencies=foresight", http.StatusInternalServerError)
+	emulateRequest(r, "/mock?proficiencies=pickpocket", http.StatusInternalServerError)
+
+	shutdown(mock)
+}
+
+func Test_GetClassByProficiencies_MATCHALL(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(3, "Wizard")
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \\(classes.id IN \\(SELECT j.class_id FROM class_proficiencies j (.+) WHERE t.deleted_at IS NULL AND LOWER\\(t.name\\) IN \\((.+)\\) GROUP BY j.class_id HAVING COUNT\\(DISTINCT LOWER\\(t.name\\)\\) = (.+)\\)\\)").WithArgs("cast_magic", "read_magic", 2).WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/mock", ch.GetByProficiencies)
+	resp := emulateRequest(r, "/mock?match=ALL&proficiencies=CAST_MAGIC&proficiencies=read_magic&proficiencies=cast_magic", http.StatusOK)
+
+	var classes []heroes.Class
+	decodeJSON(resp.Body, &classes)
+
+	if len(classes) != 1 {
+		t.Error("Invalid record found:", classes)
+	}
+
+	emulateRequest(r, "/mock?match=most&proficiencies=cast_magic", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+// matchCases are run against both the in-memory matchers and the SQL filters, so they can't drift apart.
+// The wizard being matched has the CAST_MAGIC and READ_MAGIC proficiencies.
+var matchCases = []struct {
+	match    heroes.Match
+	wanted   []heroes.ProficiencyType
+	expected bool
+	clause   string
+	args     []driver.Value
+}{
+	{heroes.MatchAny, []heroes.ProficiencyType{heroes.CastMagic, heroes.Pickpocket}, true, "classes.id IN \\(SELECT (.+) IN \\((.+)\\)\\)", []driver.Value{"cast_magic", "pickpocket"}},
+	{heroes.MatchAll, []heroes.ProficiencyType{heroes.CastMagic, heroes.ReadMagic, "CAST_MAGIC"}, true, "classes.id IN \\(SELECT (.+) GROUP BY j.class_id HAVING COUNT\\(DISTINCT LOWER\\(t.name\\)\\) = (.+)\\)", []driver.Value{"cast_magic", "read_magic", 2}},
+	{heroes.MatchAll, []heroes.ProficiencyType{heroes.CastMagic, heroes.Pickpocket}, false, "classes.id IN \\(SELECT (.+) GROUP BY j.class_id HAVING COUNT\\(DISTINCT LOWER\\(t.name\\)\\) = (.+)\\)", []driver.Value{"cast_magic", "pickpocket", 2}},
+	{heroes.MatchNone, []heroes.ProficiencyType{heroes.SimpleWeapons, heroes.Pickpocket}, true, "classes.id NOT IN \\(SELECT (.+) IN \\((.+)\\)\\)", []driver.Value{"simple_weapons", "pickpocket"}},
+	{heroes.MatchNone, []heroes.ProficiencyType{heroes.ReadMagic}, false, "classes.id NOT IN \\(SELECT (.+) IN \\((.+)\\)\\)", []driver.Value{"read_magic"}},
+}
+
+func Test_Match_INMEMORY(t *testing.T) {
+	wizard := heroes.Class{Name: "Wizard", Proficiencies: []heroes.Proficiency{{Name: heroes.CastMagic}, {Name: heroes.ReadMagic}}}
+
+	for _, c := range matchCases {
+		if got := wizard.HasProficiencies(c.match, c.wanted...); got != c.expected {
+			t.Errorf("Matching %s of %v should be %t, got %t.", c.match, c.wanted, c.expected, got)
+		}
+	}
+
+	mage := heroes.Race{Name: "Elf", RecommendedClasses: []heroes.Class{wizard}}
+	if !mage.RecommendsClasses(heroes.MatchAll, "WIZARD", "wizard") || mage.RecommendsClasses(heroes.MatchNone, "Wizard") {
+		t.Error("Invalid recommended classes match.")
+	}
+}
+
+func Test_Match_SQL(t *testing.T) {
+	for _, c := range matchCases {
+		db, mock, repository := setup()
+
+		wanted := make([]string, len(c.wanted))
+		for i, proficiency := range c.wanted {
+			wanted[i] = string(proficiency)
+		}
+
+		mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \\(" + c.clause + "\\)").WithArgs(c.args...).WillReturnRows(emptyRows)
+
+		var classes []heroes.Class
+		if err := database.ClassProficiencies.WhereMatches(repository.GetDB(), c.match, wanted).Find(&classes).Error; err != nil {
+			t.Errorf("Matching %s of %v failed: %s", c.match, c.wanted, err)
+		}
+
+		shutdown(mock)
+		db.Close()
+	}
+}
+
+func Test_GetClassByProficiencies_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	ch := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.GET("/mock", ch.GetByProficiencies)
+	emulateRequest(r, "/mock?proficiencies=cast_magic&proficiencies=foresight", http.StatusBadRequest)
 
 	shutdown(mock)
 }
@@ -508,24 +884,60 @@ func Test_GetSkillByID_OK(t *testing.T) {
 	shutdown(mock)
 }
 
-func Test_GetSkillByID_INVALID(t *testing.T) {
+func Test_GetSkillBySlug_NOTFOUND(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
 	h := controllers.NewSkillHandler(repository)
 
 	invalidID := "98a11010-d019-11ec-9d64-0242ac120002"
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"slug\" = (.+)").WithArgs(invalidID).WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(emptyRows)
+
 	r := gin.New()
 	r.GET("/:id", h.GetByID)
-	resp := emulateRequest(r, "/"+invalidID, http.StatusBadRequest)
+	resp := emulateRequest(r, "/"+invalidID, http.StatusNotFound)
 
 	body := resp.Body.String()
-	if !strings.Contains(body, invalidID) {
+	if !strings.Contains(body, invalidID) || strings.Contains(body, "Did you mean") {
 		t.Error("Invalid response error:", body)
 	}
 
 	shutdown(mock)
 }
 
+func Test_GetProficiencyByID_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewProficiencyHandler(repository)
+
+	r := gin.New()
+	r.GET("/:id", h.GetByID)
+	emulateRequest(r, "/cast_magic", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_Suggest_OK(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		distance int
+	}{
+		{"warior", "Warrior", 1},
+		{"figther", "Fighter", 1},
+		{"elfo", "Élfo", 0},
+		{"", "Elf", 3},
+		{"kitten", "sitting", 3},
+	} {
+		if distance := search.Distance(tc.a, tc.b); distance != tc.distance {
+			t.Errorf("Expected distance %d between %s and %s, found %d", tc.distance, tc.a, tc.b, distance)
+		}
+	}
+
+	if suggestions := search.Suggest("hellfyre", []string{"Fireball", "Hellfire", "Hellfire"}); len(suggestions) != 1 || suggestions[0] != "Hellfire" {
+		t.Error("Invalid suggestions found:", suggestions)
+	}
+}
+
 func Test_GetSkillByID_NOTFOUND(t *testing.T) {
 	db, mock, repository := setup()
 	defer db.Close()
@@ -571,7 +983,7 @@ func Test_GetSkillByType_NOK(t *testing.T) {
 
 	r := gin.New()
 	r.GET("/:type", h.GetByType)
-	emulateRequest(r, "/firula", http.StatusInternalServerError)
+	emulateRequest(r, "/technique", http.StatusInternalServerError)
 
 	shutdown(mock)
 }
@@ -607,15 +1019,2370 @@ func Test_GetSkillBySource_NOK(t *testing.T) {
 
 	r := gin.New()
 	r.GET("/:source", h.GetBySource)
-	emulateRequest(r, "/familiar", http.StatusInternalServerError)
+	emulateRequest(r, "/ancestor", http.StatusInternalServerError)
 
 	shutdown(mock)
 }
 
-func emulateRequest(r *gin.Engine, url string, expectedHTTPStatus int) *httptest.ResponseRecorder {
-	req := httptest.NewRequest(http.MethodGet, url, nil)
-	req.Header.Set("Content-Type", "application/json")
+func Test_GetSkills_FILTER(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "type", "level_requirement"}).AddRow(3, "Hellfire", "spell", "master")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \\(\"skills\".\"type\" = \\$1 AND \"skills\".\"level_requirement\" IN \\(\\$2,\\$3\\)\\) AND \"skills\".\"deleted_at\" IS NULL").WithArgs("spell", "advanced", "master").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/?filter="+url.QueryEscape("type eq Spell AND level_requirement in (advanced, 'master')"), http.StatusOK)
+
+	var skills []heroes.Skill
+	decodeJSON(resp.Body, &skills)
+
+	if len(skills) != 1 || skills[0].Name != "Hellfire" {
+		t.Error("Invalid records found:", skills)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetSkills_IDS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(1, "Mountain Vigor").AddRow(4, "Fireball")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" IN \\(\\$1,\\$2,\\$3\\) AND \"skills\".\"deleted_at\" IS NULL").WithArgs(4, 1, 9).WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/?ids=4,1,9,4", http.StatusOK)
+
+	var batch struct {
+		Results []heroes.Skill `json:"results"`
+		Missing []uint64       `json:"missing"`
+	}
+	decodeJSON(resp.Body, &batch)
+
+	if len(batch.Results) != 2 || batch.Results[0].ID != 4 || batch.Results[1].ID != 1 || !reflect.DeepEqual(batch.Missing, []uint64{9}) {
+		t.Error("Invalid batch found:", batch)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetSkills_IDS_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	emulateRequest(r, "/?ids=1,fireball", http.StatusBadRequest)
+	emulateRequest(r, "/?ids="+strings.Repeat("1,", 1000)+"1", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_GetSkills_ACCEPT(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+
+	for _, test := range []struct {
+		accept      string
+		status      int
+		contentType string
+		expected    string
+	}{
+		{"", http.StatusOK, "application/json", `[{"id":1,"name":"Fireball"}]`},
+		{"text/csv", http.StatusOK, "text/csv", "id,name\n1,Fireball\n"},
+		{"text/html, application/x-yaml;q=0.9", http.StatusOK, "application/yaml", "- id: 1\n  name: Fireball\n"},
+		{"application/msgpack", http.StatusOK, "application/msgpack", "\x91\x82\xa2id\x01\xa4name\xa8Fireball"},
+		{"text/html", http.StatusNotAcceptable, "application/json", "Should be one of"},
+	} {
+		mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "Fireball"))
+
+		req := httptest.NewRequest(http.MethodGet, "/?fields=id,name", nil)
+		req.Header.Set("Accept", test.accept)
+		resp := serveRequest(r, req, test.status)
+
+		body := resp.Body.String()
+		if !strings.HasPrefix(resp.Header().Get("Content-Type"), test.contentType) || !strings.Contains(body, test.expected) {
+			t.Errorf("Invalid %q response found: %q", test.accept, body)
+		}
+	}
+
+	shutdown(mock)
+}
+
+func Test_PostRaceBatch_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"races\".\"id\" IN \\(\\$1,\\$2\\)").WithArgs(2, 3).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(3, "Orc"))
+
+	r := gin.New()
+	r.POST("/batch", h.GetBatch)
+	resp := emulateAdminRequest(r, http.MethodPost, "/batch", `{"ids": [2, 3]}`, "", http.StatusOK)
+
+	var batch struct {
+		Results []heroes.Race `json:"results"`
+		Missing []uint64      `json:"missing"`
+	}
+	decodeJSON(resp.Body, &batch)
+
+	if len(batch.Results) != 1 || batch.Results[0].Name != "Orc" || !reflect.DeepEqual(batch.Missing, []uint64{2}) {
+		t.Error("Invalid batch found:", batch)
+	}
+
+	emulateAdminRequest(r, http.MethodPost, "/batch", `{"ids": "2"}`, "", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_GetClassByRole_FILTER(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \\(\"classes\".\"role\" = \\$1 AND \\(NOT \\(\"classes\".\"name\" ILIKE \\$2\\) OR \\(\"classes\".\"bonus_strength\" > \\$3 AND \"classes\".\"version\" <= \\$4\\)\\)\\)").WithArgs("fighter", "%war\\_%", 1, 2).WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/:role", h.GetByRole)
+	emulateRequest(r, "/fighter?filter="+url.QueryEscape("not name contains war_ or bonus_attributes.strength gt 1 and version le 2"), http.StatusOK)
+
+	shutdown(mock)
+}
+
+func Test_GetClasses_FILTER_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	for filter, message := range map[string]string{
+		"role eq fighter and":              "position 20: expected a field name",
+		"(role eq fighter":                 "expected ) but found",
+		"name eq 'Warrior":                 "unterminated quoted value",
+		"role in (fighter,":                "expected a value",
+		"role eq figther":                  "Role should be one of",
+		"secret eq 1":                      "unknown field \"secret\", filterable fields are: bonus_attributes.agility",
+		"name gt Warrior":                  "field name does not support the gt operator",
+		"role contains fight":              "field role does not support the contains operator",
+		"id eq one":                        "id should be a number",
+		strings.Repeat("id eq 1 or ", 100): "filters are limited to 1000 characters",
+	} {
+		resp := emulateRequest(r, "/?filter="+url.QueryEscape(filter), http.StatusBadRequest)
+
+		var found string
+		decodeJSON(resp.Body, &found)
+		if !strings.Contains(found, message) {
+			t.Errorf("Expected %q for filter %q, found: %s", message, filter, found)
+		}
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetSkillByType_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.GET("/:type", h.GetByType)
+	emulateRequest(r, "/firula", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_GetSkills_INVALIDCOLUMN(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "source"}).AddRow(1, "Mountain Vigor", "Race").AddRow(2, "War Cry", "guild")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	emulateRequest(r, "/", http.StatusInternalServerError)
+
+	shutdown(mock)
+}
+
+func Test_GetProficiencies_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewProficiencyHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(1, "simple_weapons").AddRow(3, "cast_magic")
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\"").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/", http.StatusOK)
+
+	var proficiencies []heroes.Proficiency
+	decodeJSON(resp.Body, &proficiencies)
+
+	if len(proficiencies) != 2 || proficiencies[1].Name != heroes.CastMagic {
+		t.Error("Invalid records found:", proficiencies)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetProficiencyClasses_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewProficiencyHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\" WHERE \"proficiencies\".\"id\" = (.+)").WithArgs(3).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(3, "cast_magic"))
+	rows := mock.NewRows([]string{"id", "name", "role"}).AddRow(3, "Wizard", "spellcaster")
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" INNER JOIN class_proficiencies cp (.+) WHERE cp.proficiency_id = (.+)").WithArgs(3).WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/:id/classes", h.GetClasses)
+	resp := emulateRequest(r, "/3/classes", http.StatusOK)
+
+	var classes []heroes.Class
+	decodeJSON(resp.Body, &classes)
+
+	if len(classes) != 1 || classes[0].Name != "Wizard" {
+		t.Error("Invalid records found:", classes)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetProficiencyClasses_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewProficiencyHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/:id/classes", h.GetClasses)
+	emulateRequest(r, "/1000/classes", http.StatusNotFound)
+
+	shutdown(mock)
+}
+
+func Test_SeedProficiencies_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	for i, proficiency := range []heroes.ProficiencyType{heroes.SimpleWeapons, heroes.ComplexWeapons, heroes.CastMagic, heroes.ReadMagic, heroes.Pickpocket} {
+		if i == 0 {
+			// Already stored, so it is left untouched.
+			mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\" WHERE name = (.+)").WithArgs(proficiency).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, proficiency))
+			continue
+		}
+
+		mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\" WHERE name = (.+)").WithArgs(proficiency).WillReturnRows(emptyRows)
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO \"proficiencies\" (.+)").WillReturnRows(mock.NewRows([]string{"id", "version"}).AddRow(i+1, 1))
+		mock.ExpectCommit()
+	}
+
+	seedProficiencies(repository)
+
+	shutdown(mock)
+}
+
+func Test_Search_SQL(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSearchHandler(search.NewSQLSearcher(repository.GetDB()))
+
+	tsquery := "fogo:* | infernal:*"
+	rows := mock.NewRows([]string{"type", "id", "name", "description", "rank"}).AddRow("skills", 3, "Hellfire", "Fogo do inferno.", 0.6)
+	mock.ExpectQuery("SELECT 'races' AS type, (.+) FROM races WHERE deleted_at IS NULL AND (.+) UNION ALL SELECT 'classes' (.+) UNION ALL SELECT 'skills' AS type, (.+) unaccent\\(coalesce\\(bonus, ''\\) (.+) ORDER BY rank DESC, type, id LIMIT \\$7").
+		WithArgs(tsquery, tsquery, tsquery, tsquery, tsquery, tsquery, 20).WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.Search)
+	resp := emulateRequest(r, "/?q="+url.QueryEscape("Fogo ÍNFERNAL!"), http.StatusOK)
+
+	var results []search.Result
+	decodeJSON(resp.Body, &results)
+
+	if len(results) != 1 || results[0].Type != search.Skills || results[0].ID != 3 {
+		t.Error("Invalid records found:", results)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Search_INVALID(t *testing.T) {
+	h := controllers.NewSearchHandler(search.NewIndex())
+
+	r := gin.New()
+	r.GET("/", h.Search)
+	emulateRequest(r, "/", http.StatusBadRequest)
+	emulateRequest(r, "/?q=fire&limit=none", http.StatusBadRequest)
+}
+
+func Test_Search_INMEMORY(t *testing.T) {
+	index := search.NewIndex()
+	index.Add(heroes.Race{ID: 1, Name: "Elfo", Description: "Povo da floresta, amigo da magia."})
+	index.Add(&heroes.Class{ID: 2, Name: "Mágia Arcana", Description: "Estudiosos da arte."})
+	index.Add(heroes.Skill{ID: 3, Name: "Hellfire", Description: "Incendeia tudo ao redor.", Bonus: "Dano de fogo infernal."})
+	index.Add(heroes.Skill{ID: 4, Name: "Fogo Sagrado", Observations: "Não afeta mortos-vivos."})
+	index.Add(heroes.Proficiency{ID: 5, Name: heroes.CastMagic})
+
+	results, _ := index.Search("MAGIA", 10)
+	if len(results) != 2 || results[0].Type != search.Classes || results[1].Type != search.Races {
+		t.Error("Expected accent insensitive matches ranked by field weight, got:", results)
+	}
+
+	// Names outrank bonuses, while prefixes also match.
+	results, _ = index.Search("fog", 10)
+	if len(results) != 2 || results[0].ID != 4 || results[1].ID != 3 {
+		t.Error("Expected prefix matches ranked by field weight, got:", results)
+	}
+
+	results, _ = index.Search("hellfire fogo", 1)
+	if len(results) != 1 || results[0].ID != 3 {
+		t.Error("Expected the skill matching more words first, got:", results)
+	}
+
+	index.Remove(search.Skills, 3)
+	if results, _ = index.Search("infernal", 10); len(results) != 0 {
+		t.Error("Expected removed skill to be left out, got:", results)
+	}
+}
+
+func Test_Search_LISTENER(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	index := search.NewIndex()
+	index.Add(heroes.Class{ID: 2, Name: "Mago"})
+	listener := index.Listener(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = \\$1 (.+)").WithArgs(2).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(2, "Feiticeiro"))
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+	listener(database.Change{Action: database.Updated, EntityType: "classes", EntityID: 2})
+
+	if results, _ := index.Search("mago", 10); len(results) != 0 {
+		t.Error("Expected stale name to be left out, got:", results)
+	}
+	if results, _ := index.Search("feiticeiro", 10); len(results) != 1 {
+		t.Error("Expected updated name to be found, got:", results)
+	}
+
+	listener(database.Change{Action: database.Deleted, EntityType: "classes", EntityID: 2})
+	if results, _ := index.Search("feiticeiro", 10); len(results) != 0 {
+		t.Error("Expected deleted class to be left out, got:", results)
+	}
+
+	shutdown(mock)
+}
+
+func Test_BackfillSlugs_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(1, "Anão").AddRow(2, "Alto Elfo")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE slug IS NULL OR slug = '' ORDER BY id").WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"races\" WHERE slug = (.+) AND id <> (.+)").WithArgs("anao", 1).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE \"races\" SET \"slug\"=\\$1 WHERE \"id\" = \\$2").WithArgs("anao", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT count(.+) FROM \"races\" WHERE slug = (.+) AND id <> (.+)").WithArgs("alto-elfo", 2).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE \"races\" SET \"slug\"=\\$1 WHERE \"id\" = \\$2").WithArgs("alto-elfo", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := database.BackfillSlugs(repository.GetDB(), &heroes.Race{}); err != nil {
+		t.Error("Unexpected error backfilling slugs:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SetupCache_DISABLED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	for _, ttl := range []string{"", "0"} {
+		os.Setenv("CACHE_TTL", ttl)
+		if _, ok := setupCache(repository).(*database.CachedRepository); ok {
+			t.Errorf("Expected cache to be disabled with CACHE_TTL %q.", ttl)
+		}
+	}
+	os.Setenv("CACHE_TTL", "")
+
+	shutdown(mock)
+}
+
+func Test_SetupCache_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	os.Setenv("CACHE_TTL", "1m")
+	os.Setenv("CACHE_MAX_ENTRIES", "10")
+	defer os.Setenv("CACHE_TTL", "")
+
+	cached := setupCache(repository)
+	if _, ok := cached.(*database.CachedRepository); !ok {
+		t.Error("Expected cache to be enabled with CACHE_TTL.")
+	}
+
+	os.Setenv("ADMIN_TOKEN", adminToken)
+	defer os.Setenv("ADMIN_TOKEN", "")
+
+	r := gin.New()
+	setupRoutes(r, cached, nil, nil, nil)
+	emulateRequest(r, "/cache/stats", http.StatusForbidden)
+	emulateAdminRequest(r, http.MethodGet, "/cache/stats", "", "", http.StatusOK)
+
+	shutdown(mock)
+}
+
+func Test_SetupCache_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	defer os.Setenv("CACHE_TTL", "")
+
+	for _, ttl := range []string{"forever", "-1m"} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("The code did not panic with CACHE_TTL %q.", ttl)
+				}
+			}()
+
+			os.Setenv("CACHE_TTL", ttl)
+			setupCache(repository)
+		}()
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_RACE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+
+	// The first lookup reads the race as it was before a concurrent write invalidated the cache.
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(2, "Elf")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"slug\" = (.+)").WithArgs("elf").WillReturnRows(rows).WillDelayFor(50 * time.Millisecond)
+	rows = mock.NewRows([]string{"id", "name"}).AddRow(2, "High Elf")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"slug\" = (.+)").WithArgs("elf").WillReturnRows(rows)
+
+	done := make(chan bool)
+	go func() {
+		cached.FindByField(&[]heroes.Race{}, map[string]interface{}{"slug": "elf"})
+		done <- true
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cached.Invalidate()
+	<-done
+
+	var races []heroes.Race
+	cached.FindByField(&races, map[string]interface{}{"slug": "elf"})
+
+	if len(races) != 1 || races[0].Name != "High Elf" {
+		t.Error("Expected the lookup racing with the invalidation not to be cached, found:", races)
+	}
+
+	if stats := cached.Stats(); stats.Hits != 0 || stats.Misses != 2 || stats.Entries != 1 {
+		t.Error("Unexpected cache stats:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_KEYS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+
+	for _, name := range []string{"Elf", "Dwarf"} {
+		mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"races\".\"name\" = (.+)").WithArgs(name).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, name))
+	}
+
+	for _, query := range []interface{}{&heroes.Race{Name: "Elf"}, &heroes.Race{Name: "Dwarf"}, &heroes.Race{Name: "Elf"}, &heroes.Race{Name: "Dwarf"}} {
+		cached.FindByField(&[]heroes.Race{}, query)
+	}
+
+	if stats := cached.Stats(); stats.Hits != 2 || stats.Misses != 2 {
+		t.Error("Expected queries running the same SQL to share entries:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_HIT(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+	h := controllers.NewRaceHandler(cached)
+
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(1, "Human").AddRow(2, "Elf")
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	emulateRequest(r, "/", http.StatusOK)
+	resp := emulateRequest(r, "/", http.StatusOK)
+
+	var races []heroes.Race
+	decodeJSON(resp.Body, &races)
+
+	if len(races) != 2 {
+		t.Error("Invalid records found:", races)
+	}
+
+	if stats := cached.Stats(); stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Error("Unexpected cache stats:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_INVALIDATED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+
+	rows := mock.NewRows([]string{"id", "role"}).AddRow(1, "fighter")
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"role\" = ?").WithArgs("fighter").WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"classes\" SET (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	rows = mock.NewRows([]string{"id", "role"}).AddRow(1, "fighter")
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"role\" = ?").WithArgs("fighter").WillReturnRows(rows)
+
+	var classes []heroes.Class
+	cached.FindByField(&classes, &heroes.Class{Role: heroes.Fighter})
+	cached.GetDB().Model(&heroes.Class{ID: 1}).Update("name", "Barbarian")
+	cached.FindByField(&classes, &heroes.Class{Role: heroes.Fighter})
+
+	if stats := cached.Stats(); stats.Hits != 0 || stats.Misses != 2 {
+		t.Error("Unexpected cache stats:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_EXPIRED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Millisecond, 0)
+
+	for i := 0; i < 2; i++ {
+		rows := mock.NewRows([]string{"id", "name"}).AddRow(2, "War Cry")
+		mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = ? (.+)").WithArgs(2).WillReturnRows(rows)
+		mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\" (.+)").WillReturnRows(emptyRows)
+	}
+
+	cached.FindByID(&heroes.Skill{}, 2)
+	time.Sleep(5 * time.Millisecond)
+	cached.FindByID(&heroes.Skill{}, 2)
+
+	if stats := cached.Stats(); stats.Hits != 0 || stats.Misses != 2 {
+		t.Error("Unexpected cache stats:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_EVICTED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 1)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+
+	cached.FindAll(&[]heroes.Race{})
+	cached.FindAll(&[]heroes.Class{})
+
+	if stats := cached.Stats(); stats.Evictions != 1 || stats.Entries != 1 {
+		t.Error("Unexpected cache stats:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CachedRepository_NOK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnError(errMock)
+
+	if cached.FindAll(&[]heroes.Race{}) {
+		t.Error("Expected lookup to fail.")
+	}
+
+	if stats := cached.Stats(); stats.Entries != 0 {
+		t.Error("Failed lookups should not be cached:", stats)
+	}
+
+	shutdown(mock)
+}
+
+func Test_DeleteRace_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"races\" SET \"deleted_at\"=(.+) WHERE version = (.+) AND \"races\".\"id\" = (.+) AND \"races\".\"deleted_at\" IS NULL").WithArgs(sqlmock.AnyArg(), 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.DELETE("/:id", controllers.RequireAdmin, h.Delete)
+	emulateAdminRequest(r, http.MethodDelete, "/1", "", `"1"`, http.StatusNoContent)
+
+	shutdown(mock)
+}
+
+func Test_DeleteClass_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"classes\" SET \"deleted_at\"=(.+)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count(.+) FROM \"classes\" WHERE id = (.+)").WithArgs(1000).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.DELETE("/:id", controllers.RequireAdmin, h.Delete)
+	emulateAdminRequest(r, http.MethodDelete, "/1000", "", `"1"`, http.StatusNotFound)
+
+	shutdown(mock)
+}
+
+func Test_DeleteSkill_FORBIDDEN(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.DELETE("/:id", controllers.RequireAdmin, h.Delete)
+
+	req := httptest.NewRequest(http.MethodDelete, "/1", nil)
+	req.Header.Set("Authorization", "Bearer not-the-admin")
+	serveRequest(r, req, http.StatusForbidden)
+
+	shutdown(mock)
+}
+
+func Test_RestoreSkill_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"skills\" SET \"deleted_at\"=(.+),\"version\"=version \\+ 1,\"updated_at\"=(.+) WHERE id = (.+) AND deleted_at IS NOT NULL").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	rows := mock.NewRows([]string{"id", "name"}).AddRow(3, "Hellfire")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+) AND \"skills\".\"deleted_at\" IS NULL").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/:id/restore", controllers.RequireAdmin, h.Restore)
+	resp := emulateAdminRequest(r, http.MethodPost, "/3/restore", "", "", http.StatusOK)
+
+	var skill heroes.Skill
+	decodeJSON(resp.Body, &skill)
+
+	if skill.Name != "Hellfire" {
+		t.Error("Invalid record found:", skill)
+	}
+
+	shutdown(mock)
+}
+
+func Test_RestoreRace_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"races\" SET (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/:id/restore", controllers.RequireAdmin, h.Restore)
+	emulateAdminRequest(r, http.MethodPost, "/1/restore", "", "", http.StatusNotFound)
+
+	shutdown(mock)
+}
+
+func Test_GetRaces_INCLUDEDELETED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "deleted_at"}).AddRow(1, "Human", nil).AddRow(4, "Goblin", time.Now())
+	mock.ExpectQuery("^SELECT \\* FROM \"races\"$").WillReturnRows(rows)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.GET("/", h.GetAll)
+	resp := emulateAdminRequest(r, http.MethodGet, "/?include_deleted=true", "", "", http.StatusOK)
+
+	var races []heroes.Race
+	decodeJSON(resp.Body, &races)
+
+	if len(races) != 2 || !races[1].DeletedAt.Valid {
+		t.Error("Invalid records found:", races)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassByID_ETAG(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "version"}).AddRow(1, "Warrior", 7)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = ? (.+)").WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/:id", h.GetByID)
+	resp := emulateRequest(r, "/1", http.StatusOK)
+
+	if etag := resp.Header().Get("ETag"); etag != `"7"` {
+		t.Error("Invalid ETag found:", etag)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CreateSkill_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("fireball", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("fireball-2", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"skills\" (.+) RETURNING \"id\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectCommit()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Create)
+	resp := emulateAdminRequest(r, http.MethodPost, "/", `{"name": "Fireball", "type": "spell"}`, "", http.StatusCreated)
+
+	var skill heroes.Skill
+	decodeJSON(resp.Body, &skill)
+
+	if skill.ID != 6 || skill.Version != 1 || skill.Slug != "fireball-2" || resp.Header().Get("ETag") != `"1"` {
+		t.Error("Invalid record created:", skill)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CreateSkill_SERVERFIELDS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("fireball", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"skills\" (.+) RETURNING \"id\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectCommit()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Create)
+	body := `{"id": 9, "version": 7, "created_at": "2020-01-01T00:00:00Z", "deleted_at": "2020-01-02T00:00:00Z", "name": "Fireball"}`
+	resp := emulateAdminRequest(r, http.MethodPost, "/", body, "", http.StatusCreated)
+
+	var skill heroes.Skill
+	decodeJSON(resp.Body, &skill)
+
+	if skill.ID != 6 || skill.Version != 1 || skill.CreatedAt.Year() == 2020 || skill.DeletedAt.Valid {
+		t.Error("Expected server fields to be set by the server, got:", skill)
+	}
+
+	emulateAdminRequest(r, http.MethodPost, "/", `{"name": "Meteor", "skill_requirement": [{"id": 6}]}`, "", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_CreateSkill_NUMERICSLUG(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("skill-300", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"skills\" (.+) RETURNING \"id\"").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectCommit()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Create)
+	resp := emulateAdminRequest(r, http.MethodPost, "/", `{"name": "300"}`, "", http.StatusCreated)
+
+	var skill heroes.Skill
+	decodeJSON(resp.Body, &skill)
+
+	if skill.Slug != "skill-300" {
+		t.Error("Expected numeric slugs to be prefixed, got:", skill.Slug)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CreateSkill_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Create)
+	emulateAdminRequest(r, http.MethodPost, "/", `{"name": `, "", http.StatusBadRequest)
+	emulateAdminRequest(r, http.MethodPost, "/", `{"name": "Fireball", "type": "spel"}`, "", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_UpdateClass_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"classes\" SET (.+),\"version\"=(.+) WHERE \\(id = (.+) AND version = (.+)\\) AND \"classes\".\"deleted_at\" IS NULL").WithArgs("Barbarian", "", 0, 0, 0, 0, "fighter", sqlmock.AnyArg(), 4, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	rows := mock.NewRows([]string{"id", "name", "role", "version"}).AddRow(1, "Barbarian", "fighter", 4)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = ? (.+)").WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_available_skills\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_proficiencies\" (.+)").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"class_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PUT("/:id", controllers.RequireAdmin, h.Update)
+	resp := emulateAdminRequest(r, http.MethodPut, "/1", `{"name": "Barbarian", "role": "fighter"}`, `"3"`, http.StatusOK)
+
+	if etag := resp.Header().Get("ETag"); etag != `"4"` {
+		t.Error("Invalid ETag found:", etag)
+	}
+
+	shutdown(mock)
+}
+
+func Test_UpdateClass_MISMATCH(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"classes\" SET (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count(.+) FROM \"classes\" WHERE id = (.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PUT("/:id", controllers.RequireAdmin, h.Update)
+	emulateAdminRequest(r, http.MethodPut, "/1", `{"name": "Barbarian"}`, `W/"2"`, http.StatusPreconditionFailed)
+
+	shutdown(mock)
+}
+
+func Test_UpdateClass_PRECONDITIONREQUIRED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PUT("/:id", controllers.RequireAdmin, h.Update)
+	emulateAdminRequest(r, http.MethodPut, "/1", `{"name": "Barbarian"}`, "", http.StatusPreconditionRequired)
+	emulateAdminRequest(r, http.MethodPut, "/1", `{"name": "Barbarian"}`, "*", http.StatusPreconditionFailed)
+
+	shutdown(mock)
+}
+
+func Test_PatchRace_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	for _, name := range []string{"Elf", "High Elf"} {
+		rows := mock.NewRows([]string{"id", "name", "description", "version"}).AddRow(2, name, "Pointy ears.", 1)
+		mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"races\".\"id\" = ? (.+)").WithArgs(2).WillReturnRows(rows)
+		mock.ExpectQuery("SELECT (.+) FROM \"race_available_skills\" (.+)").WillReturnRows(emptyRows)
+		mock.ExpectQuery("SELECT (.+) FROM \"race_recommended_classes\" (.+)").WillReturnRows(emptyRows)
+		mock.ExpectQuery("SELECT (.+) FROM \"race_starting_skills\" (.+)").WillReturnRows(emptyRows)
+
+		if name == "Elf" {
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE \"races\" SET \"name\"=(.+),\"description\"=(.+)").WithArgs("High Elf", "Pointy ears.", 0, 0, 0, 0, sqlmock.AnyArg(), 2, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+	}
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PATCH("/:id", controllers.RequireAdmin, h.Patch)
+	resp := emulateAdminRequest(r, http.MethodPatch, "/2", `{"name": "High Elf"}`, `"1"`, http.StatusOK)
+
+	var race heroes.Race
+	decodeJSON(resp.Body, &race)
+
+	if race.Name != "High Elf" {
+		t.Error("Invalid record found:", race)
+	}
+
+	shutdown(mock)
+}
+
+func Test_UpdateRace_ASSOCIATIONS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewRaceHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PUT("/:id", controllers.RequireAdmin, h.Update)
+	r.PATCH("/:id", controllers.RequireAdmin, h.Patch)
+
+	resp := emulateAdminRequest(r, http.MethodPut, "/2", `{"name": "Elf", "starting_skills": [{"id": 1}]}`, `"1"`, http.StatusBadRequest)
+	if !strings.Contains(resp.Body.String(), "starting_skills") {
+		t.Error("Expected the association to be named, got:", resp.Body.String())
+	}
+
+	resp = emulateAdminRequest(r, http.MethodPatch, "/2", `{"name": "Elf", "recommendedClasses": []}`, `"1"`, http.StatusBadRequest)
+	if !strings.Contains(resp.Body.String(), "recommendedClasses") {
+		t.Error("Expected the association to be named, got:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClasses_INCLUDEDELETED_FORBIDDEN(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.GET("/", h.GetAll)
+	emulateRequest(r, "/?include_deleted=true", http.StatusForbidden)
+
+	shutdown(mock)
+}
+
+func Test_AdminTokens_OK(t *testing.T) {
+	os.Setenv("ADMIN_TOKEN", "root-token")
+	os.Setenv("ADMIN_TOKENS", "alice:alice-token, bob:bob-token,invalid")
+	defer os.Setenv("ADMIN_TOKEN", "")
+	defer os.Setenv("ADMIN_TOKENS", "")
+
+	tokens := adminTokens()
+	if len(tokens) != 3 || tokens["root-token"] != "admin" || tokens["alice-token"] != "alice" || tokens["bob-token"] != "bob" {
+		t.Error("Invalid tokens found:", tokens)
+	}
+}
+
+// jsonContains matches driver arguments holding JSON documents with the provided fragment.
+type jsonContains string
+
+func (j jsonContains) Match(v driver.Value) bool {
+	document, ok := v.(string)
+	return ok && strings.Contains(document, string(j))
+}
+
+func Test_UpdateSkill_AUDITED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	repository.OnChange(database.AuditHook)
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectBegin()
+	before := mock.NewRows([]string{"id", "name", "difficulty", "version"}).AddRow(3, "Hellfire", "12", 1)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+) LIMIT 1").WithArgs(3).WillReturnRows(before)
+	mock.ExpectExec("UPDATE \"skills\" SET (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	after := mock.NewRows([]string{"id", "name", "difficulty", "version"}).AddRow(3, "Hellfire", "14", 2)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+) LIMIT 1").WithArgs(3).WillReturnRows(after)
+	mock.ExpectQuery("INSERT INTO \"audit_entries\" (.+)").
+		WithArgs("tester", sqlmock.AnyArg(), "update", "skills", 3, jsonContains(`"difficulty":"12"`), jsonContains(`"difficulty":"14"`), jsonContains(`"difficulty":{"before":"12","after":"14"}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+	rows := mock.NewRows([]string{"id", "name", "difficulty", "version"}).AddRow(3, "Hellfire", "14", 2)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = ? (.+)").WithArgs(3).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\" (.+)").WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.PUT("/:id", controllers.RequireAdmin, h.Update)
+	emulateAdminRequest(r, http.MethodPut, "/3", `{"name": "Hellfire", "difficulty": "14"}`, `"1"`, http.StatusOK)
+
+	shutdown(mock)
+}
+
+func Test_CreateRace_AUDITFAILED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	repository.OnChange(database.AuditHook)
+	h := controllers.NewRaceHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"races\" (.+)").WithArgs("goblin", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"races\" (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery("INSERT INTO \"audit_entries\" (.+)").WithArgs("tester", sqlmock.AnyArg(), "create", "races", 4, nil, jsonContains(`"name":"Goblin"`), jsonContains(`"name":{"before":null,"after":"Goblin"}`)).WillReturnError(errMock)
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Create)
+	emulateAdminRequest(r, http.MethodPost, "/", `{"name": "Goblin"}`, "", http.StatusInternalServerError)
+
+	shutdown(mock)
+}
+
+func Test_GetAudit_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewAuditHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "actor", "action", "entity_type", "entity_id", "diff"}).AddRow(2, "alice", "update", "skills", 3, `{"difficulty":{"before":"12","after":"14"}}`)
+	mock.ExpectQuery("SELECT \\* FROM \"audit_entries\" WHERE entity_type = (.+) AND entity_id = (.+) AND actor = (.+) AND timestamp >= (.+) ORDER BY id DESC LIMIT 10 OFFSET 5").WithArgs("skills", 3, "alice", sqlmock.AnyArg()).WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	resp := emulateRequest(r, "/?entity_type=skills&entity_id=3&actor=alice&since=2022-05-30T21:00:00Z&limit=10&offset=5", http.StatusOK)
+
+	var entries []database.AuditEntry
+	decodeJSON(resp.Body, &entries)
+
+	if len(entries) != 1 || !strings.Contains(string(entries[0].Diff), "difficulty") || entries[0].Before != nil {
+		t.Error("Invalid records found:", entries)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetAudit_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewAuditHandler(repository)
+
+	r := gin.New()
+	r.GET("/", h.GetAll)
+	emulateRequest(r, "/?since=yesterday", http.StatusBadRequest)
+	emulateRequest(r, "/?entity_id=elf", http.StatusBadRequest)
+	emulateRequest(r, "/?limit=0", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_GetClassHistory_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "action", "entity_type", "entity_id"}).AddRow(1, "create", "classes", 1).AddRow(5, "update", "classes", 1)
+	mock.ExpectQuery("SELECT \\* FROM \"audit_entries\" WHERE \\(entity_type = (.+) AND entity_id = (.+)\\) AND action = (.+) ORDER BY id ASC LIMIT 100").WithArgs("classes", 1, "update").WillReturnRows(rows)
+
+	r := gin.New()
+	r.GET("/:id/history", h.GetHistory)
+	resp := emulateRequest(r, "/1/history?action=update", http.StatusOK)
+
+	var entries []database.AuditEntry
+	decodeJSON(resp.Body, &entries)
+
+	if len(entries) != 2 {
+		t.Error("Invalid records found:", entries)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GetClassHistory_NOK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewClassHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"audit_entries\"").WillReturnError(errMock)
+
+	r := gin.New()
+	r.GET("/:id/history", h.GetHistory)
+	emulateRequest(r, "/1/history", http.StatusInternalServerError)
+
+	shutdown(mock)
+}
+
+func Test_DeleteSkill_EVENT(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	repository.OnChange(events.Hook)
+
+	notified := false
+	repository.OnCommit(func(change database.Change) {
+		notified = change.Action == database.Deleted && change.EntityType == "skills" && change.EntityID == 2
+	})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" (.+)").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectExec("UPDATE \"skills\" SET \"deleted_at\"=(.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" (.+)").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("INSERT INTO \"events\" (.+)").WithArgs("delete", "skills", 2, sqlmock.AnyArg()).WillReturnRows(mock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectCommit()
+
+	if err := repository.Delete(&heroes.Skill{}, 2, 1); err != nil || !notified {
+		t.Error("Expected delete to be committed and notified:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_StreamEvents_RESUME(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(id\\), 0\\) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(5))
+	rows := mock.NewRows([]string{"id", "action", "entity_type", "entity_id"}).AddRow(4, "update", "skills", 3).AddRow(5, "create", "races", 4)
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+) AND id <= (.+) ORDER BY id ASC").WithArgs(3, 5).WillReturnRows(rows)
+
+	feed, err := events.NewFeed(repository.GetDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := controllers.NewEventsHandler(feed)
+
+	r := gin.New()
+	r.GET("/", h.Stream)
+
+	// The client is already gone, so only the replayed events are streamed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/?entity=skills", nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", "3")
+	resp := serveRequest(r, req, http.StatusOK)
+
+	body := resp.Body.String()
+	if !strings.Contains(body, "id:4\nevent:skills.update\n") || strings.Contains(body, "races") {
+		t.Error("Invalid events streamed:", body)
+	}
+
+	shutdown(mock)
+}
+
+func Test_StreamEvents_NEW(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(5))
+	feed, _ := events.NewFeed(repository.GetDB())
+	h := controllers.NewEventsHandler(feed)
+
+	r := gin.New()
+	r.GET("/", h.Stream)
+
+	// Nothing is replayed, so the headers are flushed before any event.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := serveRequest(r, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), http.StatusOK)
+	if contentType := resp.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Error("Expected an event stream, got:", contentType)
+	}
+
+	shutdown(mock)
+}
+
+func Test_StreamEvents_GONE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(5000))
+	rows := mock.NewRows([]string{"id", "action", "entity_type", "entity_id"})
+	for id := 1; id <= events.MaxReplay+1; id++ {
+		rows.AddRow(id, "update", "skills", 3)
+	}
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+) AND id <= (.+) ORDER BY id ASC LIMIT 1001").WithArgs(0, 5000).WillReturnRows(rows)
+
+	feed, _ := events.NewFeed(repository.GetDB())
+	h := controllers.NewEventsHandler(feed)
+
+	r := gin.New()
+	r.GET("/", h.Stream)
+	emulateRequest(r, "/?last_event_id=0", http.StatusGone)
+
+	shutdown(mock)
+}
+
+func Test_StreamEvents_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(0))
+	feed, _ := events.NewFeed(repository.GetDB())
+	h := controllers.NewEventsHandler(feed)
+
+	r := gin.New()
+	r.GET("/", h.Stream)
+	emulateRequest(r, "/?last_event_id=latest", http.StatusBadRequest)
+
+	shutdown(mock)
+}
+
+func Test_Feed_POLL(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(7))
+	rows := mock.NewRows([]string{"id", "action", "entity_type", "entity_id"}).AddRow(8, "delete", "classes", 2)
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+) ORDER BY id ASC LIMIT 1000").WithArgs(7).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+) ORDER BY id ASC LIMIT 1000").WithArgs(8).WillReturnRows(emptyRows)
+
+	feed, _ := events.NewFeed(repository.GetDB())
+	_, live, _ := feed.Subscribe(0, false)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		feed.Run(time.Hour, stop)
+		close(done)
+	}()
+
+	feed.Notify(database.Change{})
+	if event := <-live; event.ID != 8 || event.EntityType != "classes" {
+		t.Error("Invalid event received:", event)
+	}
+
+	feed.Notify(database.Change{})
+	time.Sleep(10 * time.Millisecond)
+	close(stop)
+	<-done
+
+	feed.Unsubscribe(live)
+	if _, open := <-live; open {
+		t.Error("Expected subscription to be closed.")
+	}
+
+	shutdown(mock)
+}
+
+func Test_Feed_GAP(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	columns := []string{"id", "action", "entity_type", "entity_id"}
+	mock.ExpectQuery("SELECT (.+) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(7))
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+)").WithArgs(7).WillReturnRows(mock.NewRows(columns).AddRow(9, "create", "races", 9))
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+)").WithArgs(7).
+		WillReturnRows(mock.NewRows(columns).AddRow(8, "update", "races", 8).AddRow(9, "create", "races", 9))
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+)").WithArgs(9).WillReturnRows(mock.NewRows(columns).AddRow(11, "delete", "races", 11))
+
+	feed, _ := events.NewFeed(repository.GetDB())
+	feed.CommitLag = time.Hour
+	_, live, _ := feed.Subscribe(0, false)
+
+	// Event 8 is still being committed, so 9 waits for it.
+	feed.Poll()
+	if len(live) != 0 {
+		t.Error("Expected events after a missing one to wait for it, got:", <-live)
+	}
+
+	feed.Poll()
+	if first, second := <-live, <-live; first.ID != 8 || second.ID != 9 {
+		t.Error("Expected events to be delivered in order once the missing one is committed, got:", first, second)
+	}
+
+	// Event 10 was rolled back, so 11 is delivered once it was waited for long enough.
+	feed.CommitLag = 0
+	feed.Poll()
+	if event := <-live; event.ID != 11 {
+		t.Error("Expected missing events to be skipped after the commit lag, got:", event)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Webhook_ENQUEUE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	rows := mock.NewRows([]string{"id", "url", "entity_types", "actions"}).
+		AddRow(1, "https://example.com/all", "", "").
+		AddRow(2, "https://example.com/races", "races", "").
+		AddRow(3, "https://example.com/updates", "skills", "update")
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\"").WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"deliveries\" (.+) VALUES (.+),(.+) ON CONFLICT DO NOTHING").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	dispatcher := webhooks.NewDispatcher(repository.GetDB(), http.DefaultClient)
+	if err := dispatcher.Enqueue(events.Event{ID: 10, Action: database.Updated, EntityType: "skills", EntityID: 3}); err != nil {
+		t.Error("Expected deliveries to be enqueued:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Webhook_DELIVERED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	payload := `{"id":10,"action":"update","entity_type":"skills","entity_id":3}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhooks.SignatureHeader) != webhooks.Sign("s3cr3t", body) || r.Header.Get("X-Heroes-Event") != "skills.update" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	mock.ExpectBegin()
+	rows := mock.NewRows([]string{"id", "subscription_id", "event_id", "event_name", "payload", "status", "attempts"}).
+		AddRow(5, 1, 10, "skills.update", payload, "pending", 0)
+	mock.ExpectQuery("SELECT \\* FROM \"deliveries\" WHERE (.+) FOR UPDATE SKIP LOCKED").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\" WHERE \"subscriptions\".\"id\" = (.+)").WithArgs(1).
+		WillReturnRows(mock.NewRows([]string{"id", "url", "secret"}).AddRow(1, server.URL, "s3cr3t"))
+	mock.ExpectExec("UPDATE \"deliveries\" SET \"next_attempt_at\"=(.+),\"updated_at\"=(.+) WHERE id IN (.+)").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"deliveries\" SET (.+)").
+		WithArgs(1, 10, "skills.update", sqlmock.AnyArg(), "delivered", 1, 200, "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	dispatcher := webhooks.NewDispatcher(repository.GetDB(), server.Client())
+	if attempted, err := dispatcher.DeliverDue(); attempted != 1 || err != nil {
+		t.Error("Expected a single delivery to be attempted:", attempted, err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Webhook_DEAD(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	mock.ExpectBegin()
+	rows := mock.NewRows([]string{"id", "subscription_id", "event_id", "event_name", "payload", "status", "attempts"}).
+		AddRow(6, 1, 11, "races.delete", "{}", "pending", 2)
+	mock.ExpectQuery("SELECT \\* FROM \"deliveries\" WHERE (.+) FOR UPDATE SKIP LOCKED").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\" WHERE (.+)").WillReturnRows(mock.NewRows([]string{"id", "url"}).AddRow(1, server.URL))
+	mock.ExpectExec("UPDATE \"deliveries\" SET \"next_attempt_at\"=(.+),\"updated_at\"=(.+) WHERE id IN (.+)").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 6).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"deliveries\" SET (.+)").
+		WithArgs(1, 11, "races.delete", sqlmock.AnyArg(), "dead", 3, 503, "unexpected response status 503", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 6).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	dispatcher := webhooks.NewDispatcher(repository.GetDB(), server.Client())
+	dispatcher.MaxAttempts = 3
+	if attempted, err := dispatcher.DeliverDue(); attempted != 1 || err != nil {
+		t.Error("Expected a single delivery to be attempted:", attempted, err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Webhook_RESUME(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	columns := []string{"id", "action", "entity_type", "entity_id"}
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(id\\), 0\\) FROM \"events\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(7))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(event_id\\), 0\\) FROM \"deliveries\"").WillReturnRows(mock.NewRows([]string{"max"}).AddRow(5))
+	mock.ExpectQuery("SELECT \\* FROM \"events\" WHERE id > (.+) AND id <= (.+) ORDER BY id ASC").WithArgs(5, 7).
+		WillReturnRows(mock.NewRows(columns).AddRow(6, "create", "races", 4).AddRow(7, "update", "races", 4))
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\"").WillReturnRows(mock.NewRows([]string{"id", "url", "entity_types"}).AddRow(1, "https://example.com", "skills"))
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\"").WillReturnRows(mock.NewRows([]string{"id", "url", "entity_types"}).AddRow(1, "https://example.com", "skills"))
+
+	feed, _ := events.NewFeed(repository.GetDB())
+	dispatcher := webhooks.NewDispatcher(repository.GetDB(), http.DefaultClient)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(feed, time.Hour, stop)
+		close(done)
+	}()
+
+	// Events written while no dispatcher was running are consumed after the last enqueued one.
+	for deadline := time.Now().Add(time.Second); mock.ExpectationsWereMet() != nil && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	<-done
+
+	shutdown(mock)
+}
+
+func Test_Webhook_BACKOFF(t *testing.T) {
+	dispatcher := webhooks.NewDispatcher(nil, nil)
+
+	for attempts, expected := range map[int]time.Duration{1: 2 * time.Second, 2: 4 * time.Second, 5: 32 * time.Second, 20: time.Hour} {
+		if delay := dispatcher.Backoff(attempts); delay != expected {
+			t.Errorf("Expected backoff after %d attempts to be %s, found %s.", attempts, expected, delay)
+		}
+	}
+}
+
+func Test_CreateWebhook_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	h := controllers.NewWebhookHandler(repository, nil)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.GET("/webhooks", controllers.RequireAdmin, h.GetAll)
+	r.POST("/webhooks", controllers.RequireAdmin, h.Create)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"subscriptions\" (.+)").WithArgs("https://example.com/hook", sqlmock.AnyArg(), "skills,races", "", sqlmock.AnyArg()).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	resp := emulateAdminRequest(r, http.MethodPost, "/webhooks", `{"url": "https://example.com/hook", "entity_types": ["skills", "races"]}`, "", http.StatusCreated)
+
+	var subscription webhooks.Subscription
+	decodeJSON(resp.Body, &subscription)
+	if subscription.ID != 1 || len(subscription.Secret) != 64 {
+		t.Error("Expected subscription to be created with a generated secret:", subscription)
+	}
+
+	emulateAdminRequest(r, http.MethodPost, "/webhooks", `{"url": "not a url"}`, "", http.StatusBadRequest)
+
+	mock.ExpectQuery("SELECT \\* FROM \"subscriptions\" ORDER BY id ASC").
+		WillReturnRows(mock.NewRows([]string{"id", "url", "secret"}).AddRow(1, "https://example.com/hook", "s3cr3t"))
+	resp = emulateAdminRequest(r, http.MethodGet, "/webhooks", "", "", http.StatusOK)
+	if strings.Contains(resp.Body.String(), "s3cr3t") {
+		t.Error("Expected secrets to be hidden:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+func Test_RetryWebhook_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	h := controllers.NewWebhookHandler(repository, webhooks.NewDispatcher(repository.GetDB(), http.DefaultClient))
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/webhooks/dead-letters/:id/retry", controllers.RequireAdmin, h.Retry)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"deliveries\" SET (.+) WHERE id = (.+) AND status = (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	emulateAdminRequest(r, http.MethodPost, "/webhooks/dead-letters/9/retry", "", "", http.StatusNotFound)
+
+	shutdown(mock)
+}
+
+func Test_DeleteClass_OUTBOX(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	repository.OnChange(outbox.Hook)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" (.+)").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(2, "Thief"))
+	mock.ExpectExec("UPDATE \"classes\" SET \"deleted_at\"=(.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" (.+)").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(2, "Thief"))
+	mock.ExpectQuery("INSERT INTO \"outbox\" (.+)").
+		WithArgs("heroes.classes", "2", "classes.delete", jsonContains(`"type":"classes.delete","action":"delete","entity_type":"classes","entity_id":2`), sqlmock.AnyArg(), nil, 0, "", nil, nil).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	if err := repository.Delete(&heroes.Class{}, 2, 1); err != nil {
+		t.Error("Expected delete to be committed along with its outbox message:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_CreateSkill_OUTBOXFAILED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	repository.OnChange(outbox.Hook)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" (.+)").WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"skills\" (.+)").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectQuery("INSERT INTO \"outbox\" (.+)").WithArgs("heroes.skills", "6", "skills.create", jsonContains(`"name":"Fireball"`), sqlmock.AnyArg(), nil, 0, "", nil, nil).WillReturnError(errMock)
+	mock.ExpectRollback()
+
+	if err := repository.Create(&heroes.Skill{Name: "Fireball"}); !errors.Is(err, errMock) {
+		t.Error("Expected create to be rolled back along with its outbox message:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Relay_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	rows := mock.NewRows([]string{"id", "topic", "key", "event_name", "payload"}).
+		AddRow(1, "heroes.skills", "3", "skills.update", "{}").
+		AddRow(2, "heroes.races", "1", "races.delete", "{}")
+	mock.ExpectQuery("SELECT \\* FROM \"outbox\" WHERE published_at IS NULL AND dead_at IS NULL AND \\(claimed_until IS NULL OR claimed_until <= (.+)\\) ORDER BY id ASC LIMIT 100 FOR UPDATE SKIP LOCKED").WillReturnRows(rows)
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+) WHERE id IN \\((.+),(.+)\\)").WithArgs(sqlmock.AnyArg(), 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+),\"published_at\"=(.+) WHERE id IN \\((.+),(.+)\\)").WithArgs(nil, sqlmock.AnyArg(), 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	broker := outbox.NewMemoryBroker()
+	relay := outbox.NewRelay(repository.GetDB(), broker)
+	if published, err := relay.RelayPending(); published != 2 || err != nil {
+		t.Error("Expected every message to be published:", published, err)
+	}
+
+	if messages := broker.Messages(); len(messages) != 2 || messages[0].ID != 1 || messages[1].Topic != "heroes.races" {
+		t.Error("Invalid messages published:", messages)
+	}
+
+	shutdown(mock)
+}
+
+// failingBroker rejects a single message, accepting every other one.
+type failingBroker struct {
+	*outbox.MemoryBroker
+	failID uint64
+}
+
+func (b failingBroker) Publish(ctx context.Context, message outbox.Message) error {
+	if message.ID == b.failID {
+		return errMock
+	}
+
+	return b.MemoryBroker.Publish(ctx, message)
+}
+
+func Test_Relay_NOK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	rows := mock.NewRows([]string{"id", "topic", "key", "event_name", "payload", "attempts"}).
+		AddRow(1, "heroes.skills", "3", "skills.update", "{}", 0).
+		AddRow(2, "heroes.races", "1", "races.delete", "{}", 0).
+		AddRow(3, "heroes.races", "1", "races.restore", "{}", 0)
+	mock.ExpectQuery("SELECT \\* FROM \"outbox\" (.+) FOR UPDATE SKIP LOCKED").WillReturnRows(rows)
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+) WHERE id IN (.+)").WithArgs(sqlmock.AnyArg(), 1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+),\"published_at\"=(.+) WHERE id IN \\((.+)\\)").WithArgs(nil, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE \"outbox\" SET \"attempts\"=attempts \\+ 1,\"claimed_until\"=(.+),\"last_error\"=(.+) WHERE \"id\" = (.+)").WithArgs(nil, errMock.Error(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+) WHERE id IN \\((.+)\\)").WithArgs(nil, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	broker := failingBroker{outbox.NewMemoryBroker(), 2}
+	relay := outbox.NewRelay(repository.GetDB(), broker)
+	if published, err := relay.RelayPending(); published != 1 || !errors.Is(err, errMock) {
+		t.Error("Expected publishing to stop at the first failure:", published, err)
+	}
+
+	if messages := broker.Messages(); len(messages) != 1 || messages[0].ID != 1 {
+		t.Error("Invalid messages published:", messages)
+	}
+
+	shutdown(mock)
+}
+
+func Test_Relay_DEAD(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	rows := mock.NewRows([]string{"id", "topic", "key", "event_name", "payload", "attempts"}).AddRow(2, "heroes.races", "1", "races.delete", "{}", 2)
+	mock.ExpectQuery("SELECT \\* FROM \"outbox\" (.+) FOR UPDATE SKIP LOCKED").WillReturnRows(rows)
+	mock.ExpectExec("UPDATE \"outbox\" SET \"claimed_until\"=(.+) WHERE id IN (.+)").WithArgs(sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"outbox\" SET \"attempts\"=attempts \\+ 1,\"claimed_until\"=(.+),\"dead_at\"=(.+),\"last_error\"=(.+) WHERE \"id\" = (.+)").
+		WithArgs(nil, sqlmock.AnyArg(), errMock.Error(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	relay := outbox.NewRelay(repository.GetDB(), failingBroker{outbox.NewMemoryBroker(), 2})
+	relay.MaxAttempts = 3
+	if published, err := relay.RelayPending(); published != 0 || !errors.Is(err, errMock) {
+		t.Error("Expected the message to fail for the last time:", published, err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_NewBroker_OK(t *testing.T) {
+	if broker, err := newBroker(""); broker != nil || err != nil {
+		t.Error("Expected no broker to be configured:", broker, err)
+	}
+
+	if broker, err := newBroker("memory"); err != nil || broker == nil {
+		t.Error("Expected memory broker to be configured:", err)
+	}
+
+	if _, err := newBroker("carrier-pigeon"); err == nil {
+		t.Error("Expected unknown broker to be rejected.")
+	}
+}
+
+func Test_GraphQL_BATCHED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewGraphQLHandler(repository)
+
+	mock.ExpectQuery("SELECT \\* FROM \"races\" WHERE \"races\".\"deleted_at\" IS NULL").
+		WillReturnRows(mock.NewRows([]string{"id", "name", "version"}).AddRow(1, "Human", 1).AddRow(2, "Elf", 3))
+	mock.ExpectQuery("SELECT race_id AS owner_id, class_id AS target_id FROM \"race_recommended_classes\" WHERE race_id IN \\((.+),(.+)\\)").WithArgs(1, 2).
+		WillReturnRows(mock.NewRows([]string{"owner_id", "target_id"}).AddRow(1, 1).AddRow(1, 2).AddRow(2, 2))
+	mock.ExpectQuery("SELECT \\* FROM \"classes\" WHERE id IN \\((.+),(.+)\\) AND (.+)").WithArgs(1, 2).
+		WillReturnRows(mock.NewRows([]string{"id", "name", "role"}).AddRow(1, "Warrior", "fighter").AddRow(2, "Thief", "dexterous"))
+	mock.ExpectQuery("SELECT class_id AS owner_id, proficiency_id AS target_id FROM \"class_proficiencies\" WHERE class_id IN \\((.+),(.+)\\)").WithArgs(1, 2).
+		WillReturnRows(mock.NewRows([]string{"owner_id", "target_id"}).AddRow(1, 1).AddRow(2, 1).AddRow(2, 5))
+	mock.ExpectQuery("SELECT \\* FROM \"proficiencies\" WHERE id IN \\((.+),(.+)\\) AND (.+)").WithArgs(1, 5).
+		WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "simple_weapons").AddRow(5, "pickpocket"))
+
+	r := gin.New()
+	r.POST("/", h.Query)
+	resp := emulateAdminRequest(r, http.MethodPost, "/", `{"query": "{ races { name version recommendedClasses { name role proficiencies { name } } } }"}`, "", http.StatusOK)
+
+	expected := `{"data":{"races":[` +
+		`{"name":"Human","recommendedClasses":[{"name":"Warrior","proficiencies":[{"name":"SIMPLE_WEAPONS"}],"role":"FIGHTER"},{"name":"Thief","proficiencies":[{"name":"SIMPLE_WEAPONS"},{"name":"PICKPOCKET"}],"role":"DEXTEROUS"}],"version":1},` +
+		`{"name":"Elf","recommendedClasses":[{"name":"Thief","proficiencies":[{"name":"SIMPLE_WEAPONS"},{"name":"PICKPOCKET"}],"role":"DEXTEROUS"}],"version":3}]}}`
+	if resp.Body.String() != expected {
+		t.Error("Invalid GraphQL response:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+func Test_GraphQL_SLUG(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewGraphQLHandler(repository)
+
+	// Top level fields are resolved in no particular order.
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery("SELECT \\* FROM \"races\"").WillReturnRows(mock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "High Elf", "high-elf"))
+	mock.ExpectQuery("SELECT \\* FROM \"classes\"").WillReturnRows(mock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Warrior", "warrior"))
+	mock.ExpectQuery("SELECT \\* FROM \"skills\"").WillReturnRows(mock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "War Cry", "war-cry"))
+
+	r := gin.New()
+	r.GET("/", h.Query)
+	resp := emulateRequest(r, "/?query="+url.QueryEscape(`{ races { slug } classes { slug } skills { slug } }`), http.StatusOK)
+
+	expected := `{"data":{"classes":[{"slug":"warrior"}],"races":[{"slug":"high-elf"}],"skills":[{"slug":"war-cry"}]}}`
+	if resp.Body.String() != expected {
+		t.Error("Invalid GraphQL response:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+func Test_GraphQL_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewGraphQLHandler(repository)
+
+	mock.ExpectQuery("SELECT \\* FROM \"skills\" WHERE \"id\" = (.+)").WithArgs(9).WillReturnRows(emptyRows)
+
+	r := gin.New()
+	r.GET("/", h.Query)
+	resp := emulateRequest(r, "/?query="+url.QueryEscape(`{ skill(id: 9) { name skillRequirements { name } } }`), http.StatusOK)
+
+	if resp.Body.String() != `{"data":{"skill":null}}` {
+		t.Error("Invalid GraphQL response:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+func Test_GraphQL_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewGraphQLHandler(repository)
+
+	r := gin.New()
+	r.GET("/", h.Query)
+	emulateRequest(r, "/", http.StatusBadRequest)
+
+	resp := emulateRequest(r, "/?query="+url.QueryEscape(`{ races { strength } }`), http.StatusOK)
+	if !strings.Contains(resp.Body.String(), `Cannot query field \"strength\" on type \"Race\".`) {
+		t.Error("Expected GraphQL validation error:", resp.Body.String())
+	}
+
+	shutdown(mock)
+}
+
+// dialGRPC serves the gRPC API in memory and connects a client to it.
+func dialGRPC(repository database.Repository) (*grpc.ClientConn, func()) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := rpc.Register(repository)
+	go server.Serve(listener)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to dial gRPC server, got error: %v", err))
+	}
+
+	return conn, func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func Test_GRPC_GetSkill_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	conn, stop := dialGRPC(repository)
+	defer stop()
+
+	rows := mock.NewRows([]string{"id", "name", "type", "source", "activation", "version"}).AddRow(3, "Hellfire", "spell", "class", "action", 2)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = ? (.+)").WithArgs(3).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\" (.+)").WillReturnRows(emptyRows)
+
+	skill, err := heroespb.NewHeroesServiceClient(conn).GetSkill(context.Background(), &heroespb.GetSkillRequest{Id: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if skill.GetName() != "Hellfire" || skill.GetType() != heroespb.SkillType_SKILL_TYPE_SPELL || skill.GetSource() != heroespb.Source_SOURCE_CLASS ||
+		skill.GetActivation() != heroespb.Activation_ACTIVATION_ACTION || skill.GetMetadata().GetVersion() != 2 {
+		t.Error("Invalid record found:", skill)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GRPC_GetRace_NOTFOUND(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	conn, stop := dialGRPC(repository)
+	defer stop()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"races\".\"id\" = ? (.+)").WithArgs(9).WillReturnRows(emptyRows)
+
+	_, err := heroespb.NewHeroesServiceClient(conn).GetRace(context.Background(), &heroespb.GetRaceRequest{Id: 9})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Expected race not to be found:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GRPC_ListClasses_ROLE(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	conn, stop := dialGRPC(repository)
+	defer stop()
+
+	rows := mock.NewRows([]string{"id", "name", "role"}).AddRow(3, "Wizard", "spellcaster")
+	mock.ExpectQuery("SELECT \\* FROM \"classes\" WHERE \"classes\".\"role\" = (.+)").WithArgs("spellcaster").WillReturnRows(rows)
+
+	response, err := heroespb.NewHeroesServiceClient(conn).ListClasses(context.Background(), &heroespb.ListClassesRequest{Role: heroespb.Role_ROLE_SPELLCASTER})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if classes := response.GetClasses(); len(classes) != 1 || classes[0].GetRole() != heroespb.Role_ROLE_SPELLCASTER {
+		t.Error("Invalid records found:", classes)
+	}
+
+	_, err = heroespb.NewHeroesServiceClient(conn).ListClasses(context.Background(), &heroespb.ListClassesRequest{Role: 42})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Expected unknown role to be rejected:", err)
+	}
+
+	shutdown(mock)
+}
+
+func Test_GRPC_HEALTH(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	conn, stop := dialGRPC(repository)
+	defer stop()
+
+	response, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "heroes.v1.HeroesService"})
+	if err != nil || response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Error("Expected service to be serving:", response, err)
+	}
+
+	services := rpc.Register(repository).GetServiceInfo()
+	if _, found := services["grpc.reflection.v1alpha.ServerReflection"]; !found {
+		t.Error("Expected server reflection to be registered:", services)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SetupGRPC_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	os.Setenv("GRPC_ADDRESS", "localhost:0")
+	defer os.Setenv("GRPC_ADDRESS", "")
+	setupGRPC(repository).Stop()
+
+	shutdown(mock)
+}
+
+func Test_ExportCompendium_CSV(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	cached := database.NewCachedRepository(repository, time.Minute, 0)
+	h := controllers.NewCompendiumHandler(cached)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"deleted_at\" IS NULL").WillReturnRows(mock.NewRows([]string{"id", "name", "slug", "type", "mana", "version"}).AddRow(2, "Hellfire", "hellfire", "spell", "5", 1).AddRow(1, "Fireball", "fireball", "spell", "2", 3))
+	mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\" WHERE \"skill_requirements\".\"skill_id\" IN \\(\\$1,\\$2\\)").WithArgs(2, 1).WillReturnRows(mock.NewRows([]string{"skill_id", "skill_requirement_id"}).AddRow(2, 1))
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = \\$1").WithArgs(1).WillReturnRows(mock.NewRows([]string{"id", "name", "slug"}).AddRow(1, "Fireball", "fireball"))
+
+	r := gin.New()
+	r.GET("/", h.Export)
+	resp := emulateRequest(r, "/?format=csv&entity=skills", http.StatusOK)
+
+	expected := "id,slug,name,description,bonus,mana,difficulty_type,difficulty,activation,source,type,level_requirement,skill_requirements,observations,version\n" +
+		"1,fireball,Fireball,,,2,,,,,spell,,,,3\n" +
+		"2,hellfire,Hellfire,,,5,,,,,spell,,fireball,,1\n"
+	if resp.Body.String() != expected || !strings.HasPrefix(resp.Header().Get("Content-Type"), "text/csv") {
+		t.Error("Invalid export:", resp.Body.String())
+	}
+
+	// Sorting the export must leave the cached entities as the database returned them.
+	var skills []heroes.Skill
+	if cached.Expand("SkillRequirements").FindAll(&skills); len(skills) != 2 || skills[0].ID != 2 {
+		t.Error("Cached skills were reordered:", skills)
+	}
+
+	shutdown(mock)
+}
+
+func Test_ImportCompendium_CSV(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewCompendiumHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(mock.NewRows([]string{"id", "name", "slug", "description", "type", "version"}).AddRow(1, "Fireball", "fireball", "A ball of fire.", "spell", 2))
+	mock.ExpectQuery("SELECT (.+) FROM \"skill_requirements\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("fireball", 1).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE \"skills\" SET (.+) WHERE \\(id = \\$15 AND version = \\$16\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT count(.+) FROM \"skills\" WHERE slug = (.+) AND id <> (.+)").WithArgs("hellfire", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"skills\" (.+) RETURNING \"id\"").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(emptyRows)
+	mock.ExpectExec("UPDATE \"skills\" SET \"updated_at\"=\\$1 WHERE (.+)\"id\" = \\$2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO \"skill_requirements\" (.+) ON CONFLICT DO NOTHING").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM \"skill_requirements\" WHERE \"skill_requirements\".\"skill_id\" = \\$1 AND \"skill_requirements\".\"skill_requirement_id\" <> \\$2").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Import)
+	body := "slug,name,description,bonus,mana,difficulty_type,difficulty,activation,source,type,level_requirement,skill_requirements,observations,version\n" +
+		"fireball,Fireball,Hurls a ball of fire.,,,,,,,spell,,,,2\n" +
+		",Hellfire,,,,,,,,spell,,Fireball,,\n"
+	resp := emulateAdminRequest(r, http.MethodPost, "/?format=csv&entity=skills", body, "", http.StatusOK)
+
+	var report compendium.Report
+	decodeJSON(resp.Body, &report)
+
+	if len(report.Created) != 1 || report.Created[0].Key != "hellfire" || report.Created[0].ID != 2 || len(report.Updated) != 1 || report.Updated[0].ID != 1 {
+		t.Error("Invalid report:", report)
+	}
+
+	shutdown(mock)
+}
+
+func Test_ImportCompendium_DRYRUN(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewCompendiumHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\"").WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(3, "cast_magic"))
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(emptyRows)
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Import)
+	resp := emulateAdminRequest(r, http.MethodPost, "/?format=yaml&dry_run=true", "proficiencies:\n- proficiency_type: cast_magic\n", "", http.StatusOK)
+
+	var report compendium.Report
+	decodeJSON(resp.Body, &report)
+
+	if !report.DryRun || len(report.Unchanged) != 1 || report.Unchanged[0].ID != 3 || len(report.Created)+len(report.Updated)+len(report.Conflicts) != 0 {
+		t.Error("Invalid report:", report)
+	}
+
+	shutdown(mock)
+}
+
+func Test_ImportCompendium_CONFLICTS(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewCompendiumHandler(repository)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"proficiencies\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"races\"").WillReturnRows(mock.NewRows([]string{"id", "name", "slug", "version"}).AddRow(1, "Elf", "elf", 3))
+	mock.ExpectQuery("SELECT (.+) FROM \"race_available_skills\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"race_recommended_classes\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT (.+) FROM \"race_starting_skills\"").WillReturnRows(emptyRows)
+	mock.ExpectQuery("SELECT count(.+) FROM \"races\" WHERE slug = (.+) AND id <> (.+)").WithArgs("orc", 0).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("INSERT INTO \"races\" (.+) RETURNING \"id\"").WillReturnRows(mock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectRollback()
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Import)
+	body := `{"races": [{"id": 1, "name": "Elf", "description": "Pointy ears.", "version": 1}, {"id": 9, "name": "Orc"}, {"name": "Orc"}, {"name": "orc"}]}`
+	resp := emulateAdminRequest(r, http.MethodPost, "/", body, "", http.StatusConflict)
+
+	var report compendium.Report
+	decodeJSON(resp.Body, &report)
+
+	messages := make([]string, len(report.Conflicts))
+	for i, conflict := range report.Conflicts {
+		messages[i] = conflict.Message
+	}
+
+	expected := []string{"was changed since exported: version 1 is now 3", "there is no races with ID 9", "appears more than once"}
+	if !reflect.DeepEqual(messages, expected) || len(report.Created) != 1 || report.Created[0].ID != 0 {
+		t.Error("Invalid report:", report)
+	}
+
+	shutdown(mock)
+}
+
+func Test_ImportCompendium_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewCompendiumHandler(repository)
+
+	r := gin.New()
+	r.Use(controllers.Authenticate(map[string]string{adminToken: "tester"}))
+	r.POST("/", controllers.RequireAdmin, h.Import)
+	for query, body := range map[string]string{
+		"/?format=csv":                 "name\nFireball\n",
+		"/?format=xml":                 "",
+		"/?format=csv&entity=monsters": "",
+		"/?format=csv&entity=races":    "name,wings\nElf,2\n",
+		"/?format=csv&entity=classes":  "name\nWarrior\n",
+		"/?format=csv&entity=skills":   "name,description,bonus,mana,difficulty_type,difficulty,activation,source,type,level_requirement,skill_requirements,observations\nFireball,,,,,,,,spel,,,\n",
+		"/?format=json":                `{"skills": [{"name": "Fireball", "mana_cost": 2}]}`,
+	} {
+		emulateAdminRequest(r, http.MethodPost, query, body, "", http.StatusBadRequest)
+	}
+
+	shutdown(mock)
+}
+
+func Test_OpenAPI_DRIFT(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	r := gin.New()
+	setupRoutes(r, database.NewCachedRepository(repository, time.Minute, 0), nil, nil, nil)
+
+	specs := apiSpecs(openapi.NewGenerator(openapi.Info{}))
+	for _, route := range r.Routes() {
+		key := route.Method + " " + route.Path
+		if _, found := specs[key]; !found {
+			t.Errorf("Route %s is missing from apiSpecs.", key)
+		}
+		delete(specs, key)
+	}
+
+	for key := range specs {
+		t.Errorf("Route %s is documented in apiSpecs but no longer registered.", key)
+	}
+
+	_, undocumented := apiDocument(r.Routes())
+	if len(undocumented) > 0 {
+		t.Error("Expected every route to be documented:", undocumented)
+	}
+
+	shutdown(mock)
+}
+
+func Test_OpenAPI_OK(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+
+	r := gin.New()
+	setupRoutes(r, repository, nil, nil, nil)
+	resp := emulateRequest(r, "/openapi.json", http.StatusOK)
+
+	var document openapi.Document
+	decodeJSON(resp.Body, &document)
+
+	race := document.Components.Schemas["Race"]
+	if race == nil || race.Properties["recommendedClasses"].Items.Ref != "#/components/schemas/Class" || race.Properties["starting_skills"] == nil || race.Properties["version"] == nil {
+		t.Error("Invalid Race schema:", race)
+	}
+
+	if role := document.Components.Schemas["Role"]; role == nil || fmt.Sprint(role.Enum) != "[fighter spellcaster dexterous]" {
+		t.Error("Invalid Role schema:", role)
+	}
+
+	if skill := document.Components.Schemas["Skill"]; skill == nil || skill.Properties["skill_requirement"].Items.Ref != "#/components/schemas/Skill" {
+		t.Error("Invalid Skill schema:", skill)
+	}
+
+	operation := document.Paths["/races/{id}"]["delete"]
+	if operation == nil || operation.OperationID != "RaceHandler.Delete" || operation.Responses["412"].Description == "" || len(operation.Security) != 1 {
+		t.Error("Invalid delete operation:", operation)
+	}
+
+	if operation := document.Paths["/classes/by-role/{role}"]["get"]; operation == nil || operation.Parameters[0].Schema.Ref != "#/components/schemas/Role" {
+		t.Error("Invalid by-role operation:", operation)
+	}
+
+	if document.Paths["/graphql"]["get"].OperationID == document.Paths["/graphql"]["post"].OperationID {
+		t.Error("Expected operation IDs to be unique.")
+	}
+
+	shutdown(mock)
+}
+
+func Test_RollDice_SEED(t *testing.T) {
+	h := controllers.NewDiceHandler()
+
+	r := gin.New()
+	r.GET("/", h.Roll)
+
+	var first, second controllers.DiceRoll
+	decodeJSON(emulateRequest(r, "/?notation=4d6kh3+2&seed=42", http.StatusOK).Body, &first)
+	decodeJSON(emulateRequest(r, "/?notation=4D6%20KH3%20%2B%202&seed=42", http.StatusOK).Body, &second)
+
+	if !reflect.DeepEqual(first, second) || first.Notation != "4d6kh3+2" || first.Seed != 42 {
+		t.Error("Expected the same roll from the same seed:", first, second)
+	}
+
+	dice, kept := first.Terms[0].Dice, 0
+	for _, die := range dice {
+		if !die.Dropped {
+			kept += die.Value
+		}
+	}
+
+	if len(dice) != 4 || kept+2 != first.Total || first.Terms[1].Total != 2 {
+		t.Error("Invalid roll found:", first)
+	}
+}
+
+func Test_RollDice_EXPLODE(t *testing.T) {
+	h := controllers.NewDiceHandler()
+
+	r := gin.New()
+	r.GET("/", h.Roll)
+
+	var roll controllers.DiceRoll
+	decodeJSON(emulateRequest(r, "/?notation=10d2!-1d4&seed=7", http.StatusOK).Body, &roll)
+
+	exploded, total := 0, 0
+	for _, die := range roll.Terms[0].Dice {
+		if die.Exploded != (die.Value == 2) {
+			t.Error("Invalid exploding die found:", die)
+		}
+		if die.Exploded {
+			exploded++
+		}
+		total += die.Value
+	}
+
+	if len(roll.Terms[0].Dice) != 10+exploded || roll.Terms[0].Total != total || roll.Terms[1].Total > -1 {
+		t.Error("Invalid roll found:", roll)
+	}
+}
+
+func Test_RollDice_INVALID(t *testing.T) {
+	h := controllers.NewDiceHandler()
+
+	r := gin.New()
+	r.GET("/", h.Roll)
+	emulateRequest(r, "/", http.StatusBadRequest)
+	emulateRequest(r, "/?notation=2d6&seed=lucky", http.StatusBadRequest)
+
+	for notation, message := range map[string]string{
+		"2d6+":    "position 5: expected a number or dice",
+		"2d":      "position 3: expected the number of sides",
+		"2d6kh3":  "should keep from 1 to 2 dice",
+		"1000d6":  "should roll from 1 to 100 dice",
+		"2d6 3":   "position 5: expected + or -",
+		"1d1":     "dice should have from 2 to 1000 sides",
+		"3d6kx":   "expected kh or kl",
+		"d20+fly": "position 5: expected a number or dice",
+	} {
+		resp := emulateRequest(r, "/?notation="+url.PathEscape(notation), http.StatusBadRequest)
+		if body := resp.Body.String(); !strings.Contains(body, message) {
+			t.Errorf("Invalid error for %s: %s", notation, body)
+		}
+	}
+}
+
+func Test_SkillCheck_FIXED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "difficulty_type", "difficulty"}).AddRow(3, "Hellfire", "fixed", "12")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(3).WillReturnRows(rows)
+
+	r := gin.New()
+	r.POST("/:id/check", h.Check)
+	resp := emulateAdminRequest(r, http.MethodPost, "/3/check", `{"attributes": {"intelligence": 4}, "attribute": "Intelligence", "seed": 42}`, "", http.StatusOK)
+
+	var check controllers.SkillCheck
+	decodeJSON(resp.Body, &check)
+
+	if check.Roll == nil || check.Roll.Notation != "3d6" || check.Roll.Seed != 42 || check.Attribute != "intelligence" {
+		t.Fatal("Invalid roll found:", check)
+	}
+
+	// Seed 42 rolls 6, 6 and 3.
+	if check.Roll.Total != 15 || check.Total != 19 || check.Target != 12 || check.Margin != 7 || check.Outcome != controllers.Success {
+		t.Error("Invalid check found:", check)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SkillCheck_SUBTRACTED(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	rows := mock.NewRows([]string{"id", "name", "difficulty_type", "difficulty"}).AddRow(3, "Hellfire", "fixed", "12")
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(3).WillReturnRows(rows)
+
+	r := gin.New()
+	r.POST("/:id/check", h.Check)
+	resp := emulateAdminRequest(r, http.MethodPost, "/3/check", `{"attributes": {"intelligence": 4}, "attribute": "intelligence", "dice": "1d6-1d6", "seed": 74}`, "", http.StatusOK)
+
+	var check controllers.SkillCheck
+	decodeJSON(resp.Body, &check)
+
+	// Seed 74 rolls a 6 and subtracts a 1, which is the best roll possible.
+	if check.Roll == nil || check.Roll.Total != 5 || check.Total != 9 || check.Margin != -3 || check.Outcome != controllers.CriticalSuccess {
+		t.Error("Invalid check found:", check)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SkillCheck_CHARACTER(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(6).
+		WillReturnRows(mock.NewRows([]string{"id", "name", "difficulty_type", "difficulty"}).AddRow(6, "Backstab", "target_plus", "+2"))
+	mock.ExpectQuery("SELECT (.+) FROM \"races\" WHERE \"races\".\"id\" = (.+)").WithArgs(2).
+		WillReturnRows(mock.NewRows([]string{"id", "name", "base_agility"}).AddRow(2, "Elf", 3))
+	mock.ExpectQuery("SELECT (.+) FROM \"classes\" WHERE \"classes\".\"id\" = (.+)").WithArgs(4).
+		WillReturnRows(mock.NewRows([]string{"id", "name", "bonus_agility"}).AddRow(4, "Rogue", 2))
+
+	r := gin.New()
+	r.POST("/:id/check", h.Check)
+	resp := emulateAdminRequest(r, http.MethodPost, "/6/check", `{"race_id": 2, "class_id": 4, "attribute": "agility", "target": 9, "dice": "2d6", "seed": 7}`, "", http.StatusOK)
+
+	var check controllers.SkillCheck
+	decodeJSON(resp.Body, &check)
+
+	if check.AttributeValue != 5 || check.Target != 11 || check.Roll == nil || check.Total != 5+check.Roll.Total {
+		t.Error("Invalid check found:", check)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SkillCheck_AUTO(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(2).
+		WillReturnRows(mock.NewRows([]string{"id", "name", "difficulty_type"}).AddRow(2, "War Cry", "auto"))
+
+	r := gin.New()
+	r.POST("/:id/check", h.Check)
+	resp := emulateAdminRequest(r, http.MethodPost, "/2/check", `{}`, "", http.StatusOK)
+
+	var check controllers.SkillCheck
+	decodeJSON(resp.Body, &check)
+
+	if check.Outcome != controllers.Success || check.Roll != nil {
+		t.Error("Invalid check found:", check)
+	}
+
+	shutdown(mock)
+}
+
+func Test_SkillCheck_INVALID(t *testing.T) {
+	db, mock, repository := setup()
+	defer db.Close()
+	h := controllers.NewSkillHandler(repository)
+
+	r := gin.New()
+	r.POST("/:id/check", h.Check)
+
+	for body, message := range map[string]string{
+		`{"attributes": {"strength": 1}, "attribute": "strength"}`:                              "the game master should send the target",
+		`{"attributes": {"strength": 1}, "race_id": 1, "attribute": "strength", "target": 10}`:  "not both",
+		`{"attributes": {"strength": 1}, "attribute": "charisma", "target": 10}`:                "Invalid attribute received",
+		`{"attributes": {"strength": 1}, "attribute": "strength", "target": 10, "dice": "2x6"}`: "Invalid dice: position 2",
+	} {
+		mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(7).
+			WillReturnRows(mock.NewRows([]string{"id", "name", "difficulty_type"}).AddRow(7, "Levitate", "variable"))
+
+		resp := emulateAdminRequest(r, http.MethodPost, "/7/check", body, "", http.StatusBadRequest)
+		if !strings.Contains(resp.Body.String(), message) {
+			t.Errorf("Invalid error for %s: %s", body, resp.Body.String())
+		}
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM \"skills\" WHERE \"skills\".\"id\" = (.+)").WithArgs(1000).WillReturnRows(emptyRows)
+	emulateAdminRequest(r, http.MethodPost, "/1000/check", `{}`, "", http.StatusNotFound)
+
+	shutdown(mock)
+}
+
+func Test_GetEnums_OK(t *testing.T) {
+	h := controllers.NewMetaHandler()
+
+	r := gin.New()
+	r.GET("/", h.GetEnums)
+	resp := emulateRequest(r, "/", http.StatusOK)
+
+	var enums []heroes.Enum
+	decodeJSON(resp.Body, &enums)
+
+	values := map[string][]string{}
+	for _, enum := range enums {
+		for _, value := range enum.Values {
+			if value.Label == "" || value.Description == "" {
+				t.Error("Missing label or description:", enum.Name, value.Value)
+			}
+			values[enum.Name] = append(values[enum.Name], value.Value)
+		}
+	}
+
+	if len(enums) != 7 || fmt.Sprint(values["Role"]) != "[fighter spellcaster dexterous]" {
+		t.Error("Invalid enums found:", values)
+	}
+}
+
+func Test_GetEnums_NEGOTIATED(t *testing.T) {
+	h := controllers.NewMetaHandler()
+
+	r := gin.New()
+	r.GET("/", h.GetEnums)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/yaml")
+	resp := serveRequest(r, req, http.StatusOK)
+
+	if contentType := resp.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/yaml") || !strings.Contains(resp.Body.String(), "name: Role") {
+		t.Errorf("Invalid %s response found: %s", contentType, resp.Body.String())
+	}
+
+	if body := emulateRequest(r, "/", http.StatusOK).Body.String(); strings.Contains(body, "\n") {
+		t.Error("Expected compact JSON without ?pretty, found:", body)
+	}
+}
+
+func Test_Enums_DRIFT(t *testing.T) {
+	db, _, repository := setup()
+	defer db.Close()
+
+	r := gin.New()
+	setupRoutes(r, repository, nil, nil, nil)
+	document, _ := apiDocument(r.Routes())
+	schema, err := graph.NewSchema(repository)
+	if err != nil {
+		t.Fatal("Unable to build the GraphQL schema:", err)
+	}
+
+	for _, enum := range heroes.Enums() {
+		if documented := document.Components.Schemas[enum.Name]; documented == nil || fmt.Sprint(documented.Enum) != fmt.Sprint(enum.Allowed()) {
+			t.Errorf("Expected OpenAPI %s to have the values %v, got: %v", enum.Name, enum.Allowed(), documented)
+		}
+
+		var values []string
+		if graphqlEnum, ok := schema.Type(enum.Name).(*graphql.Enum); ok {
+			for _, value := range graphqlEnum.Values() {
+				values = append(values, fmt.Sprint(value.Value))
+			}
+		}
+		sort.Strings(values)
+
+		allowed := enum.Allowed()
+		sort.Strings(allowed)
+		if fmt.Sprint(values) != fmt.Sprint(allowed) {
+			t.Errorf("Expected GraphQL %s to have the values %v, got: %v", enum.Name, allowed, values)
+		}
+	}
+}
+
+func emulateRequest(r *gin.Engine, url string, expectedHTTPStatus int) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	return serveRequest(r, req, expectedHTTPStatus)
+}
+
+func emulateAdminRequest(r *gin.Engine, method string, url string, body string, ifMatch string, expectedHTTPStatus int) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	return serveRequest(r, req, expectedHTTPStatus)
+}
 
+func serveRequest(r *gin.Engine, req *http.Request, expectedHTTPStatus int) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/compendium"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/controllers"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/filter"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/openapi"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/search"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/webhooks"
+)
+
+// apiDocument describes the registered routes as an OpenAPI document, also listing routes missing from apiSpecs.
+func apiDocument(routes gin.RoutesInfo) (openapi.Document, []string) {
+	generator := openapi.NewGenerator(openapi.Info{
+		Title:       "Heroes",
+		Description: "Races, classes and skills of the Mighty Blade tabletop RPG.",
+		Version:     "1.0.0",
+	})
+
+	generator.Enum(enumValues[heroes.Role]()...)
+	generator.Enum(enumValues[heroes.ProficiencyType]()...)
+	generator.Enum(enumValues[heroes.DifficultyType]()...)
+	generator.Enum(enumValues[heroes.Activation]()...)
+	generator.Enum(enumValues[heroes.Source]()...)
+	generator.Enum(enumValues[heroes.SkillType]()...)
+	generator.Enum(enumValues[heroes.LevelRequirement]()...)
+	generator.Enum(heroes.MatchAny, heroes.MatchAll, heroes.MatchNone)
+	generator.Enum(database.Created, database.Updated, database.Deleted, database.Restored)
+	generator.Enum(webhooks.Pending, webhooks.Delivered, webhooks.Dead)
+	generator.Enum(compendium.JSON, compendium.YAML, compendium.CSV)
+	generator.Enum(controllers.CriticalSuccess, controllers.Success, controllers.Failure, controllers.CriticalFailure)
+
+	return generator.Build(routes, apiSpecs(generator))
+}
+
+// enumValues lists the allowed values of the heroes enum T, so the document never drifts from heroes.Enums.
+func enumValues[T ~string]() []interface{} {
+	var values []interface{}
+	for _, value := range heroes.Values[T]() {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// unnegotiated routes ignore Accept: GraphQL and this document always respond with JSON, and exports with ?format=.
+var unnegotiated = map[string]bool{"GET /graphql": true, "POST /graphql": true, "GET /openapi.json": true, "GET /export": true}
+
+// apiSpecs documents every route registered by setupRoutes, keyed by "METHOD /path".
+// Test_OpenAPI_DRIFT fails whenever a route is added or removed without updating this list.
+func apiSpecs(g *openapi.Generator) map[string]openapi.Spec {
+	specs := map[string]openapi.Spec{}
+
+	includeDeleted := g.QueryParam("include_deleted", true, "Also returns soft deleted resources. Administrators only.")
+	fields := g.QueryParam("fields", "", "Comma separated JSON fields to return, nested ones joined by dots, such as `name,starting_skills.name`.")
+	ids := g.QueryParam("ids", "", "Comma separated IDs to find instead of listing everything. Responds with the results in the same order, along with the IDs not found.")
+	expand := g.QueryParam("expand", "", "Comma separated associations to include, nested ones joined by dots, such as `recommended_classes.proficiencies`. Single resources include their direct associations by default, lists include none.")
+	limit := g.QueryParam("limit", 0, "Lists at most this many results, ordered by ID. Lists aren't paginated without limit or offset.")
+	offset := g.QueryParam("offset", 0, "Skips this many results, ordered by ID.")
+	entities := []struct {
+		path   string
+		tag    string
+		name   string
+		entity interface{}
+		list   interface{}
+		fields filter.Fields
+	}{
+		{"/races", "races", "race", heroes.Race{}, []heroes.Race{}, controllers.RaceFilters},
+		{"/classes", "classes", "class", heroes.Class{}, []heroes.Class{}, controllers.ClassFilters},
+		{"/skills", "skills", "skill", heroes.Skill{}, []heroes.Skill{}, controllers.SkillFilters},
+	}
+
+	idOrSlug := map[string]interface{}{"id": ""}
+	idOrSlugDescription := map[string]string{"id": "Numeric ID or slug, such as `12` or `high-elf`."}
+	for _, e := range entities {
+		specs["GET "+e.path] = openapi.Spec{Summary: "Lists every " + e.name + ".", Tag: e.tag, Query: []openapi.Parameter{includeDeleted, fields, expand, ids, filterParam(g, e.fields), limit, offset}, Response: e.list}
+		specs["POST "+e.path+"/batch"] = openapi.Spec{Summary: "Finds every " + e.name + " with the IDs sent, in the same order, along with the IDs not found.", Tag: e.tag, Query: []openapi.Parameter{includeDeleted, fields, expand, filterParam(g, e.fields)}, Request: controllers.BatchRequest{}, Response: batchOf(e.list)}
+		specs["GET "+e.path+"/:id"] = openapi.Spec{Summary: "Finds a " + e.name + " along with its associations, by ID or slug.", Tag: e.tag, Query: []openapi.Parameter{includeDeleted, fields, expand}, PathTypes: idOrSlug, PathDescriptions: idOrSlugDescription, Response: e.entity}
+		specs["GET "+e.path+"/by-name/:name"] = openapi.Spec{Summary: "Finds a " + e.name + " by name or slug, ignoring case and accents. Suggests similar names when not found.", Tag: e.tag, Query: []openapi.Parameter{includeDeleted, fields, expand}, Response: e.entity}
+		specs["POST "+e.path] = openapi.Spec{Summary: "Creates a " + e.name + ". IDs, versions and timestamps are set by the server, and associations are rejected.", Tag: e.tag, Request: e.entity, Response: e.entity, Status: http.StatusCreated, Admin: true}
+		specs["PUT "+e.path+"/:id"] = openapi.Spec{Summary: "Replaces every field. Associations are rejected.", Tag: e.tag, Request: e.entity, Response: e.entity, Admin: true, Versioned: true}
+		specs["PATCH "+e.path+"/:id"] = openapi.Spec{Summary: "Changes only the fields sent. Associations are rejected.", Tag: e.tag, Request: map[string]interface{}{}, Response: e.entity, Admin: true, Versioned: true}
+		specs["DELETE "+e.path+"/:id"] = openapi.Spec{Summary: "Soft deletes it.", Tag: e.tag, Status: http.StatusNoContent, Admin: true, Versioned: true}
+		specs["POST "+e.path+"/:id/restore"] = openapi.Spec{Summary: "Restores it after a soft delete.", Tag: e.tag, Response: e.entity, Admin: true}
+		specs["GET "+e.path+"/:id/history"] = openapi.Spec{Summary: "Lists its audit entries, oldest first.", Tag: e.tag, Query: auditQuery(g), Response: []database.AuditEntry{}, Admin: true}
+	}
+
+	match := g.QueryParam("match", heroes.MatchAny, "`any` (default) keeps results having at least one of the values, `all` those having every one of them and `none` those having none of them.")
+	specs["GET /races/by-recommended-classes"] = openapi.Spec{
+		Summary:  "Lists races recommending any, all or none of the provided classes, according to `match`.",
+		Tag:      "races",
+		Query:    []openapi.Parameter{includeDeleted, fields, expand, match, g.QueryParam("classes", []string{}, "Class names, case insensitive.")},
+		Response: []heroes.Race{},
+	}
+	specs["GET /classes/by-role/:role"] = openapi.Spec{
+		Summary:   "Lists classes of the provided role.",
+		Tag:       "classes",
+		Query:     []openapi.Parameter{includeDeleted, fields, expand, filterParam(g, controllers.ClassFilters), limit, offset},
+		PathTypes: map[string]interface{}{"role": heroes.Role("")},
+		Response:  []heroes.Class{},
+	}
+	specs["GET /classes/by-proficiencies"] = openapi.Spec{
+		Summary:  "Lists classes having any, all or none of the provided proficiencies, according to `match`.",
+		Tag:      "classes",
+		Query:    []openapi.Parameter{includeDeleted, fields, expand, match, g.QueryParam("proficiencies", []heroes.ProficiencyType{}, "Case insensitive.")},
+		Response: []heroes.Class{},
+	}
+	specs["GET /skills/by-type/:type"] = openapi.Spec{
+		Summary:   "Lists skills of the provided type.",
+		Tag:       "skills",
+		Query:     []openapi.Parameter{includeDeleted, fields, expand, filterParam(g, controllers.SkillFilters), limit, offset},
+		PathTypes: map[string]interface{}{"type": heroes.SkillType("")},
+		Response:  []heroes.Skill{},
+	}
+	specs["POST /skills/:id/check"] = openapi.Spec{
+		Summary:  "Resolves the skill performed by a character or raw attributes: the attribute plus dice against its difficulty.",
+		Tag:      "skills",
+		Request:  controllers.SkillCheckRequest{},
+		Response: controllers.SkillCheck{},
+	}
+	specs["GET /skills/by-source/:source"] = openapi.Spec{
+		Summary:   "Lists skills learnt from the provided source.",
+		Tag:       "skills",
+		Query:     []openapi.Parameter{includeDeleted, fields, expand, filterParam(g, controllers.SkillFilters), limit, offset},
+		PathTypes: map[string]interface{}{"source": heroes.Source("")},
+		Response:  []heroes.Skill{},
+	}
+
+	specs["GET /proficiencies"] = openapi.Spec{Summary: "Lists every proficiency.", Tag: "proficiencies", Query: []openapi.Parameter{includeDeleted, fields, filterParam(g, controllers.ProficiencyFilters), limit, offset}, Response: []heroes.Proficiency{}}
+	specs["GET /proficiencies/:id"] = openapi.Spec{Summary: "Finds a proficiency.", Tag: "proficiencies", Query: []openapi.Parameter{includeDeleted, fields}, Response: heroes.Proficiency{}}
+	specs["GET /proficiencies/:id/classes"] = openapi.Spec{Summary: "Lists classes having the proficiency.", Tag: "proficiencies", Query: []openapi.Parameter{includeDeleted, fields, expand}, Response: []heroes.Class{}}
+
+	compendiumQuery := []openapi.Parameter{
+		g.QueryParam("format", compendium.JSON, ""),
+		g.QueryParam("entity", "", "Only this entity type: "+strings.Join(compendium.Entities, ", ")+". Required by CSV, which holds a single one."),
+	}
+	specs["GET /export"] = openapi.Spec{
+		Summary:  "Exports every race, class, skill and proficiency, referencing associations by slug.",
+		Tag:      "compendium",
+		Query:    compendiumQuery,
+		Response: compendium.Compendium{},
+	}
+	specs["POST /import"] = openapi.Spec{
+		Summary:  "Creates and updates entities in the same formats as the export, all at once or not at all. Conflicts respond 409 with the report.",
+		Tag:      "compendium",
+		Query:    append(compendiumQuery, g.QueryParam("dry_run", true, "Only reports what would be created, updated and conflicting.")),
+		Request:  compendium.Compendium{},
+		Response: compendium.Report{},
+		Admin:    true,
+	}
+
+	specs["GET /search"] = openapi.Spec{
+		Summary:  "Searches races, classes and skills by words of their names, descriptions, bonuses and observations, best matches first.",
+		Tag:      "search",
+		Query:    []openapi.Parameter{g.QueryParam("q", "", "Words to search for, case and accent insensitive."), g.QueryParam("limit", 0, ""), g.QueryParam("offset", 0, "")},
+		Response: []search.Result{},
+	}
+
+	specs["GET /dice/roll"] = openapi.Spec{
+		Summary: "Rolls dice, answering every die rolled along with the total.",
+		Tag:     "dice",
+		Query: []openapi.Parameter{
+			g.QueryParam("notation", "", "Dice notation, such as `2d6+3`, `4d6kh3` to keep the 3 highest dice, or `3d6!` for exploding dice. Plus signs don't need to be escaped."),
+			g.QueryParam("seed", int64(0), "Reproduces a previous roll. Rolls are random when absent."),
+		},
+		Response: controllers.DiceRoll{},
+	}
+
+	specs["GET /audit"] = openapi.Spec{
+		Summary:  "Lists audit entries, latest first.",
+		Tag:      "audit",
+		Query:    append(auditQuery(g), g.QueryParam("entity_type", "", "Table name, such as races."), g.QueryParam("entity_id", uint64(0), "")),
+		Response: []database.AuditEntry{},
+		Admin:    true,
+	}
+
+	specs["GET /graphql"] = openapi.Spec{
+		Summary:  "Executes a GraphQL query sent as query parameters.",
+		Tag:      "graphql",
+		Query:    []openapi.Parameter{g.QueryParam("query", "", ""), g.QueryParam("operationName", "", "")},
+		Response: map[string]interface{}{},
+	}
+	specs["POST /graphql"] = openapi.Spec{Summary: "Executes a GraphQL query.", Tag: "graphql", Request: map[string]interface{}{}, Response: map[string]interface{}{}}
+
+	specs["GET /events"] = openapi.Spec{
+		Summary:     "Streams change events as server-sent events. Resumes after the Last-Event-ID header, if sent, answering 410 when too many events happened since.",
+		Tag:         "events",
+		Query:       []openapi.Parameter{g.QueryParam("last_event_id", uint64(0), "Same as the Last-Event-ID header."), g.QueryParam("entity", []string{}, "Table names to stream events of.")},
+		Response:    events.Event{},
+		ContentType: "text/event-stream",
+	}
+
+	deliveriesQuery := []openapi.Parameter{g.QueryParam("status", webhooks.Status(""), ""), g.QueryParam("limit", 0, ""), g.QueryParam("offset", 0, "")}
+	specs["GET /webhooks"] = openapi.Spec{Summary: "Lists webhook subscriptions. Secrets are omitted.", Tag: "webhooks", Response: []webhooks.Subscription{}, Admin: true}
+	specs["POST /webhooks"] = openapi.Spec{Summary: "Subscribes to change events. A secret is generated when none is sent.", Tag: "webhooks", Request: webhooks.Subscription{}, Response: webhooks.Subscription{}, Status: http.StatusCreated, Admin: true}
+	specs["GET /webhooks/:id"] = openapi.Spec{Summary: "Finds a webhook subscription. Its secret is omitted.", Tag: "webhooks", Response: webhooks.Subscription{}, Admin: true}
+	specs["DELETE /webhooks/:id"] = openapi.Spec{Summary: "Removes a webhook subscription along with its deliveries.", Tag: "webhooks", Status: http.StatusNoContent, Admin: true}
+	specs["GET /webhooks/:id/deliveries"] = openapi.Spec{Summary: "Lists deliveries of a subscription, latest first.", Tag: "webhooks", Query: deliveriesQuery, Response: []webhooks.Delivery{}, Admin: true}
+	specs["GET /webhooks/dead-letters"] = openapi.Spec{Summary: "Lists deliveries that ran out of attempts.", Tag: "webhooks", Query: deliveriesQuery, Response: []webhooks.Delivery{}, Admin: true}
+	specs["POST /webhooks/dead-letters/:id/retry"] = openapi.Spec{Summary: "Retries a dead delivery.", Tag: "webhooks", Status: http.StatusAccepted, Admin: true}
+
+	specs["GET /cache/stats"] = openapi.Spec{Summary: "Reports cache effectiveness, when caching is enabled.", Tag: "cache", Response: database.CacheStats{}, Admin: true}
+	specs["GET /meta/enums"] = openapi.Spec{Summary: "Lists every enum with its allowed values, labels and descriptions.", Tag: "meta", Response: []heroes.Enum{}}
+	specs["GET /openapi.json"] = openapi.Spec{Summary: "This document.", Tag: "meta", Response: map[string]interface{}{}}
+
+	// Responses are written in the media type negotiated through Accept, unless the route sets its own.
+	pretty := g.QueryParam("pretty", true, "Indents JSON responses.")
+	for key, spec := range specs {
+		if spec.Response != nil && spec.ContentType == "" && !unnegotiated[key] {
+			spec.Alternatives = controllers.MediaTypes[1:]
+			spec.Query = append(append([]openapi.Parameter{}, spec.Query...), pretty)
+			specs[key] = spec
+		}
+	}
+
+	return specs
+}
+
+// batchOf describes controllers.Batch holding results of the same type as list, since Batch itself can't tell.
+func batchOf(list interface{}) interface{} {
+	batch := reflect.TypeOf(controllers.Batch{})
+	results, _ := batch.FieldByName("Results")
+	missing, _ := batch.FieldByName("Missing")
+	results.Type = reflect.TypeOf(list)
+
+	return reflect.New(reflect.StructOf([]reflect.StructField{results, missing})).Elem().Interface()
+}
+
+// filterParam documents ?filter= along with the fields it accepts.
+func filterParam(g *openapi.Generator, fields filter.Fields) openapi.Parameter {
+	description := "Filter expression, like `type eq spell and level_requirement in (advanced, master)`. Operators are eq, ne, gt, ge, lt, le, contains and in, combined with and, or, not and parentheses. Filterable fields: "
+	return g.QueryParam("filter", "", description+strings.Join(fields.Names(), ", ")+".")
+}
+
+func auditQuery(g *openapi.Generator) []openapi.Parameter {
+	return []openapi.Parameter{
+		g.QueryParam("actor", "", ""),
+		g.QueryParam("action", database.Action(""), ""),
+		g.QueryParam("since", "", "RFC 3339 timestamp, inclusive."),
+		g.QueryParam("until", "", "RFC 3339 timestamp, exclusive."),
+		g.QueryParam("limit", 0, ""),
+		g.QueryParam("offset", 0, ""),
+	}
+}
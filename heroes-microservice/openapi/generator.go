@@ -0,0 +1,285 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Spec documents a route. Path parameters are found in the route itself, so only what gin can't tell is here.
+type Spec struct {
+	Summary string
+	Tag     string
+	// Query parameters accepted by the route.
+	Query []Parameter
+	// PathTypes gives a sample value of path parameters that are not IDs, such as heroes.Role for :role.
+	PathTypes map[string]interface{}
+	// PathDescriptions explains path parameters that need it, such as an :id also accepting slugs.
+	PathDescriptions map[string]string
+	// Request is a sample value of the JSON body, if any.
+	Request interface{}
+	// Response is a sample value of the JSON response, if any.
+	Response interface{}
+	// Status of a successful response, 200 when zero.
+	Status int
+	// ContentType of a successful response, application/json when empty.
+	ContentType string
+	// Alternatives are other media types the successful response can be negotiated into through Accept.
+	Alternatives []string
+	// Admin routes require an administrator token.
+	Admin bool
+	// Versioned routes require If-Match with the ETag of the current version.
+	Versioned bool
+}
+
+var pathParams = regexp.MustCompile(`:([^/]+)`)
+
+// Generator builds a Document from the routes registered in gin and the Go types they read and write.
+type Generator struct {
+	info    Info
+	enums   map[reflect.Type][]interface{}
+	schemas map[string]*Schema
+}
+
+// NewGenerator constructs a Generator for the provided API description.
+func NewGenerator(info Info) *Generator {
+	return &Generator{info: info, enums: make(map[reflect.Type][]interface{}), schemas: make(map[string]*Schema)}
+}
+
+// Enum registers the allowed values of a string type, such as heroes.Role. Every value must share the same type.
+func (g *Generator) Enum(values ...interface{}) {
+	for _, value := range values {
+		t := reflect.TypeOf(value)
+		g.enums[t] = append(g.enums[t], value)
+	}
+}
+
+// QueryParam builds a Spec query parameter from the sample value of its type.
+func (g *Generator) QueryParam(name string, sample interface{}, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: g.schemaOf(reflect.TypeOf(sample))}
+}
+
+// Build documents every registered route using the spec found under "METHOD /path", as in "GET /races/:id".
+// Routes without a spec are still documented, with a bare operation, and are reported as undocumented.
+func (g *Generator) Build(routes gin.RoutesInfo, specs map[string]Spec) (Document, []string) {
+	document := Document{
+		OpenAPI: "3.0.3",
+		Info:    g.info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:         g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{"admin": {Type: "http", Scheme: "bearer"}},
+		},
+	}
+
+	operationIDs := map[string]int{}
+	var undocumented []string
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		spec, found := specs[key]
+		if !found {
+			undocumented = append(undocumented, key)
+		}
+
+		path := pathParams.ReplaceAllString(route.Path, "{$1}")
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+
+		operation := g.operation(route, spec)
+		if operationIDs[operation.OperationID]++; operationIDs[operation.OperationID] > 1 {
+			operation.OperationID += route.Method[:1] + strings.ToLower(route.Method[1:])
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	sort.Strings(undocumented)
+	return document, undocumented
+}
+
+func (g *Generator) operation(route gin.RouteInfo, spec Spec) *Operation {
+	operation := &Operation{
+		OperationID: operationID(route.Handler),
+		Summary:     spec.Summary,
+		Responses:   map[string]Response{},
+	}
+
+	if spec.Tag != "" {
+		operation.Tags = []string{spec.Tag}
+	}
+
+	for _, match := range pathParams.FindAllStringSubmatch(route.Path, -1) {
+		schema := &Schema{Type: "integer", Format: "int64"}
+		if sample, found := spec.PathTypes[match[1]]; found {
+			schema = g.schemaOf(reflect.TypeOf(sample))
+		}
+		operation.Parameters = append(operation.Parameters, Parameter{Name: match[1], In: "path", Description: spec.PathDescriptions[match[1]], Required: true, Schema: schema})
+	}
+	operation.Parameters = append(operation.Parameters, spec.Query...)
+
+	if spec.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: g.schemaOf(reflect.TypeOf(spec.Request))}},
+		}
+	}
+
+	status := spec.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := Response{Description: http.StatusText(status)}
+	if spec.Response != nil {
+		contentType := spec.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		schema := g.schemaOf(reflect.TypeOf(spec.Response))
+		success.Content = map[string]MediaType{contentType: {Schema: schema}}
+		for _, alternative := range spec.Alternatives {
+			success.Content[alternative] = MediaType{Schema: schema}
+		}
+	}
+
+	if spec.Versioned || (route.Method == http.MethodGet && strings.HasSuffix(route.Path, "/:id") && isEntity(spec.Response)) {
+		success.Headers = map[string]Header{"ETag": {Schema: &Schema{Type: "string"}}}
+	}
+	operation.Responses[fmt.Sprint(status)] = success
+
+	message := map[string]MediaType{"application/json": {Schema: &Schema{Type: "string"}}}
+	operation.Responses["400"] = Response{Description: "Invalid request", Content: message}
+	if strings.Contains(route.Path, ":id") {
+		operation.Responses["404"] = Response{Description: "Resource not found", Content: message}
+	}
+
+	if spec.Admin {
+		operation.Security = []map[string][]string{{"admin": {}}}
+		operation.Responses["403"] = Response{Description: "Administrator token required", Content: message}
+	}
+
+	if spec.Versioned {
+		operation.Parameters = append(operation.Parameters, Parameter{Name: "If-Match", In: "header", Required: true, Description: "ETag of the version being changed.", Schema: &Schema{Type: "string"}})
+		operation.Responses["412"] = Response{Description: "Resource was changed by someone else", Content: message}
+		operation.Responses["428"] = Response{Description: "If-Match header is required", Content: message}
+	}
+
+	operation.Responses["500"] = Response{Description: "Unexpected failure", Content: message}
+	return operation
+}
+
+// isEntity tells whether the sample value carries a version, so responses come with an ETag.
+func isEntity(sample interface{}) bool {
+	if sample == nil {
+		return false
+	}
+
+	_, ok := reflect.New(reflect.TypeOf(sample)).Interface().(interface{ GetVersion() uint64 })
+	return ok
+}
+
+// operationID derives a stable ID from the handler name, such as "RaceHandler.GetAll".
+func operationID(handler string) string {
+	handler = strings.TrimSuffix(handler, "-fm")
+	if i := strings.LastIndex(handler, "/"); i >= 0 {
+		handler = handler[i+1:]
+	}
+
+	handler = handler[strings.Index(handler, ".")+1:]
+	return strings.NewReplacer("(*", "", ")", "").Replace(handler)
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+	rawJSONType   = reflect.TypeOf((*interface{ MarshalJSON() ([]byte, error) })(nil)).Elem()
+)
+
+// schemaOf describes a Go type the way encoding/json writes it. Named structs and enums become components.
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == deletedAtType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	case t.Kind() != reflect.Struct && t.Implements(rawJSONType):
+		return &Schema{}
+	}
+
+	if values, found := g.enums[t]; found {
+		if _, found := g.schemas[t.Name()]; !found {
+			g.schemas[t.Name()] = &Schema{Type: "string", Enum: values}
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := g.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		copied := *schema
+		copied.Nullable = true
+		return &copied
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		if _, found := g.schemas[t.Name()]; !found {
+			// Registered before describing fields, so recursive types such as heroes.Skill end up as references.
+			g.schemas[t.Name()] = &Schema{}
+			*g.schemas[t.Name()] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+// object lists the properties of a struct, with embedded structs flattened as encoding/json does.
+func (g *Generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for property, fieldSchema := range g.object(field.Type).Properties {
+				schema.Properties[property] = fieldSchema
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schemaOf(field.Type)
+	}
+
+	return schema
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// Header names sent along with every published message, so consumers can route and deduplicate them.
+const (
+	MessageIDHeader = "Heroes-Message-Id"
+	EventHeader     = "Heroes-Event"
+)
+
+// Broker publishes outbox messages to a message broker.
+// Publish must only return once the broker acknowledged the message, since the message is then marked as published.
+type Broker interface {
+	Publish(ctx context.Context, message Message) error
+	Close() error
+}
+
+// headers lists the metadata every adapter attaches to the published message.
+func headers(message Message) map[string]string {
+	return map[string]string{
+		MessageIDHeader: strconv.FormatUint(message.ID, 10),
+		EventHeader:     message.EventName,
+	}
+}
+
+// MemoryBroker keeps published messages in memory. Useful for tests and local development.
+type MemoryBroker struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// NewMemoryBroker constructs an empty MemoryBroker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+// Publish appends the message to the ones published so far.
+func (b *MemoryBroker) Publish(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.messages = append(b.messages, message)
+	return nil
+}
+
+// Messages returns a copy of every message published so far, in publishing order.
+func (b *MemoryBroker) Messages() []Message {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return append([]Message{}, b.messages...)
+}
+
+// Close does nothing, as there is no connection to release.
+func (b *MemoryBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaBroker publishes messages to Kafka, using the topic as is and the entity ID as message key.
+// Messages are partitioned by key, so changes of the same entity are consumed in order.
+type KafkaBroker struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaBroker constructs a KafkaBroker writing to the provided bootstrap servers.
+// Publishing waits for every in-sync replica to acknowledge the message.
+func NewKafkaBroker(brokers ...string) *KafkaBroker {
+	return &KafkaBroker{&kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}}
+}
+
+// Publish writes the message and waits for the acknowledgement.
+func (b *KafkaBroker) Publish(ctx context.Context, message Message) error {
+	var kafkaHeaders []kafka.Header
+	for name, value := range headers(message) {
+		kafkaHeaders = append(kafkaHeaders, kafka.Header{Key: name, Value: []byte(value)})
+	}
+
+	return b.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   message.Topic,
+		Key:     []byte(message.Key),
+		Value:   message.Payload,
+		Headers: kafkaHeaders,
+	})
+}
+
+// Close flushes pending writes and releases the connections.
+func (b *KafkaBroker) Close() error {
+	return b.writer.Close()
+}
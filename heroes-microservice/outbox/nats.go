@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSBroker publishes messages to NATS JetStream, using the topic as subject.
+// A stream must capture the subjects (e.g. "heroes.>"), otherwise publishing fails as it is never acknowledged.
+// The message ID is also sent as Nats-Msg-Id, so JetStream drops duplicates within its deduplication window.
+type NATSBroker struct {
+	conn      *nats.Conn
+	jetStream nats.JetStreamContext
+}
+
+// NewNATSBroker connects to the NATS server at the provided URL.
+func NewNATSBroker(url string) (*NATSBroker, error) {
+	conn, err := nats.Connect(url, nats.Name("heroes-microservice"))
+	if err != nil {
+		return nil, err
+	}
+
+	jetStream, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &NATSBroker{conn, jetStream}, nil
+}
+
+// Publish sends the message and waits for JetStream to acknowledge it.
+func (b *NATSBroker) Publish(ctx context.Context, message Message) error {
+	msg := nats.NewMsg(message.Topic)
+	msg.Data = message.Payload
+	for name, value := range headers(message) {
+		msg.Header.Set(name, value)
+	}
+
+	_, err := b.jetStream.PublishMsg(msg, nats.Context(ctx), nats.MsgId(strconv.FormatUint(message.ID, 10)))
+	return err
+}
+
+// Close drains pending messages and closes the connection.
+func (b *NATSBroker) Close() error {
+	return b.conn.Drain()
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"gorm.io/gorm"
+)
+
+// TopicPrefix is prepended to the entity type to name the topic (or subject) messages are published to.
+const TopicPrefix = "heroes."
+
+// Message is a domain event waiting in the outbox to be published, or already published when PublishedAt is set.
+// Messages of the same entity share the same Key, so brokers that partition by key keep them in order.
+type Message struct {
+	ID          uint64        `json:"id" gorm:"primary_key"`
+	Topic       string        `json:"topic"`
+	Key         string        `json:"key"`
+	EventName   string        `json:"event_name"`
+	Payload     database.JSON `json:"payload"`
+	CreatedAt   time.Time     `json:"created_at"`
+	PublishedAt *time.Time    `json:"published_at" gorm:"index"`
+	Attempts    int           `json:"attempts"`
+	LastError   string        `json:"last_error"`
+	// ClaimedUntil keeps the message from other relays while one is publishing it.
+	ClaimedUntil *time.Time `json:"claimed_until"`
+	// DeadAt is set once the message ran out of attempts. Dead messages are no longer published.
+	DeadAt *time.Time `json:"dead_at" gorm:"index"`
+}
+
+// TableName keeps the outbox table name short and explicit.
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// DomainEvent is the payload of every published message.
+type DomainEvent struct {
+	Type       string          `json:"type"`
+	Action     database.Action `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint64          `json:"entity_id"`
+	Actor      string          `json:"actor"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Before     interface{}     `json:"before"`
+	After      interface{}     `json:"after"`
+}
+
+// Hook is a ChangeHook writing a Message for every change, in the same transaction as the change.
+// Either both the change and its message are committed, or none of them are.
+func Hook(tx *gorm.DB, change *database.Change) error {
+	message, err := NewMessage(change)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&message).Error
+}
+
+// NewMessage describes the provided change as a Message to be published.
+func NewMessage(change *database.Change) (Message, error) {
+	event := DomainEvent{
+		Type:       fmt.Sprintf("%s.%s", change.EntityType, change.Action),
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Actor:      change.Actor,
+		Timestamp:  change.Time,
+		Before:     change.Before,
+		After:      change.After,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Topic:     TopicPrefix + change.EntityType,
+		Key:       strconv.FormatUint(change.EntityID, 10),
+		EventName: event.Type,
+		Payload:   database.JSON(payload),
+	}, nil
+}
@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Relay publishes outbox messages to a Broker, in the order they were written as far as a single relay goes: several
+// relays claim different batches, so messages published by one may overtake the ones being published by another.
+// A message is only marked as published after the broker acknowledged it, so delivery is at-least-once: a crash in
+// between publishes the message again, and consumers should deduplicate by MessageIDHeader.
+type Relay struct {
+	db     *gorm.DB
+	broker Broker
+	nudge  chan struct{}
+
+	// BatchSize caps how many messages are published by a single RelayPending call.
+	BatchSize int
+	// Timeout bounds how long the broker may take to acknowledge a single message.
+	Timeout time.Duration
+	// Lease is how long claimed messages are kept from other relays while being published. Messages left behind by a
+	// relay stopping midway are published again once it expires.
+	Lease time.Duration
+	// MaxAttempts before a message is considered dead and no longer holds back the ones written after it.
+	MaxAttempts int
+}
+
+// NewRelay constructs a Relay publishing up to 100 messages at once, each within 10s, giving up on messages after 10
+// attempts.
+func NewRelay(db *gorm.DB, broker Broker) *Relay {
+	return &Relay{
+		db:          db,
+		broker:      broker,
+		nudge:       make(chan struct{}, 1),
+		BatchSize:   100,
+		Timeout:     10 * time.Second,
+		Lease:       30 * time.Minute,
+		MaxAttempts: 10,
+	}
+}
+
+// Notify is a ChangeListener waking the relay up as soon as a local write is committed.
+func (r *Relay) Notify(database.Change) {
+	select {
+	case r.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes pending messages until stop is closed. Messages left behind by failures or by other instances are
+// picked up every interval.
+func (r *Relay) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-r.nudge:
+		}
+
+		// Keep going while there are full batches, so a burst of changes is not throttled by the ticker.
+		for {
+			if published, err := r.RelayPending(); err != nil || published < r.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+// RelayPending publishes the oldest pending messages it claims, returning how many were published.
+// Publishing stops at the first failure, so this relay never publishes a message before the ones claimed ahead of it.
+// No transaction is held open while publishing: messages are claimed for the Lease first, and marked afterwards.
+func (r *Relay) RelayPending() (published int, err error) {
+	claimed, err := r.claim()
+	if err != nil {
+		log.Println("Error while relaying outbox messages: ", err)
+		return 0, err
+	}
+
+	var ids []uint64
+	var publishErr error
+	for _, message := range claimed {
+		if publishErr = r.publish(message); publishErr != nil {
+			break
+		}
+		ids = append(ids, message.ID)
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if len(ids) > 0 {
+			if err := tx.Model(&Message{}).Where("id IN ?", ids).Updates(map[string]interface{}{"published_at": now, "claimed_until": nil}).Error; err != nil {
+				return err
+			}
+		}
+
+		if publishErr == nil {
+			return nil
+		}
+
+		failed := claimed[len(ids)]
+		changes := map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": publishErr.Error(), "claimed_until": nil}
+		if failed.Attempts+1 >= r.MaxAttempts {
+			log.Printf("Outbox message %d is dead after %d attempts. Err: %s", failed.ID, failed.Attempts+1, publishErr)
+			changes["dead_at"] = now
+		}
+		if err := tx.Model(&failed).Updates(changes).Error; err != nil {
+			return err
+		}
+
+		// The messages after the failed one are released, so they are claimed again right away in the same order.
+		var remaining []uint64
+		for _, message := range claimed[len(ids)+1:] {
+			remaining = append(remaining, message.ID)
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		return tx.Model(&Message{}).Where("id IN ?", remaining).Update("claimed_until", nil).Error
+	})
+
+	if err == nil {
+		err = publishErr
+	}
+
+	if err != nil {
+		log.Println("Error while relaying outbox messages: ", err)
+	}
+
+	return len(ids), err
+}
+
+// claim locks the oldest pending messages that are neither dead nor claimed by another relay, skipping the ones
+// locked by others, and claims them for the Lease before committing.
+func (r *Relay) claim() (claimed []Message, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND dead_at IS NULL AND (claimed_until IS NULL OR claimed_until <= ?)", now).
+			Order("id ASC").Limit(r.BatchSize).Find(&claimed).Error; err != nil {
+			return err
+		}
+
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(claimed))
+		for i, message := range claimed {
+			ids[i] = message.ID
+		}
+
+		return tx.Model(&Message{}).Where("id IN ?", ids).Update("claimed_until", now.Add(r.Lease)).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *Relay) publish(message Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+
+	return r.broker.Publish(ctx, message)
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/heroespb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Enum values are mapped both ways, so unknown values end up as the UNSPECIFIED value or as an empty string.
+var (
+	roles             = enumMap[heroes.Role, heroespb.Role](heroespb.Role_value)
+	proficiencyTypes  = enumMap[heroes.ProficiencyType, heroespb.ProficiencyType](heroespb.ProficiencyType_value)
+	difficultyTypes   = enumMap[heroes.DifficultyType, heroespb.DifficultyType](heroespb.DifficultyType_value)
+	activations       = enumMap[heroes.Activation, heroespb.Activation](heroespb.Activation_value)
+	sources           = enumMap[heroes.Source, heroespb.Source](heroespb.Source_value)
+	skillTypes        = enumMap[heroes.SkillType, heroespb.SkillType](heroespb.SkillType_value)
+	levelRequirements = enumMap[heroes.LevelRequirement, heroespb.LevelRequirement](heroespb.LevelRequirement_value)
+)
+
+// enumMap pairs every value of the heroes enum T with the protobuf value named after both, such as ROLE_FIGHTER for
+// fighter. Values missing from the protobuf enum panic, so heroes.Enums and heroes.proto never drift apart.
+func enumMap[T ~string, P ~int32](numbers map[string]int32) map[T]P {
+	prefix := strings.ToUpper(snakeCase(reflect.TypeOf(T("")).Name())) + "_"
+
+	mapped := map[T]P{}
+	for _, value := range heroes.Values[T]() {
+		name := prefix + strings.ToUpper(string(value))
+		number, ok := numbers[name]
+		if !ok {
+			panic(fmt.Sprintf("rpc: %s is missing from heroes.proto", name))
+		}
+		mapped[value] = P(number)
+	}
+
+	return mapped
+}
+
+// snakeCase turns Go type names into snake case, such as SkillType into skill_type.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+func toRace(race heroes.Race) *heroespb.Race {
+	return &heroespb.Race{
+		Id:                 race.ID,
+		Name:               race.Name,
+		Slug:               race.Slug,
+		Description:        race.Description,
+		BaseAttributes:     toAttributes(race.BaseAttributes),
+		StartingSkills:     toSkills(race.StartingSkills),
+		AvailableSkills:    toSkills(race.AvailableSkills),
+		RecommendedClasses: toClasses(race.RecommendedClasses),
+		Metadata:           toMetadata(race.Timestamps, race.Revision),
+	}
+}
+
+func toRaces(races []heroes.Race) []*heroespb.Race {
+	result := make([]*heroespb.Race, len(races))
+	for i, race := range races {
+		result[i] = toRace(race)
+	}
+
+	return result
+}
+
+func toClass(class heroes.Class) *heroespb.Class {
+	return &heroespb.Class{
+		Id:              class.ID,
+		Name:            class.Name,
+		Slug:            class.Slug,
+		Description:     class.Description,
+		BonusAttributes: toAttributes(class.BonusAttributes),
+		Role:            roles[class.Role],
+		Proficiencies:   toProficiencies(class.Proficiencies),
+		StartingSkills:  toSkills(class.StartingSkills),
+		AvailableSkills: toSkills(class.AvailableSkills),
+		Metadata:        toMetadata(class.Timestamps, class.Revision),
+	}
+}
+
+func toClasses(classes []heroes.Class) []*heroespb.Class {
+	result := make([]*heroespb.Class, len(classes))
+	for i, class := range classes {
+		result[i] = toClass(class)
+	}
+
+	return result
+}
+
+func toSkill(skill heroes.Skill) *heroespb.Skill {
+	return &heroespb.Skill{
+		Id:                skill.ID,
+		Name:              skill.Name,
+		Slug:              skill.Slug,
+		Description:       skill.Description,
+		Bonus:             skill.Bonus,
+		Mana:              skill.Mana,
+		DifficultyType:    difficultyTypes[skill.DifficultyType],
+		Difficulty:        skill.Difficulty,
+		Activation:        activations[skill.Activation],
+		Source:            sources[skill.Source],
+		Type:              skillTypes[skill.Type],
+		LevelRequirement:  levelRequirements[skill.LevelRequirement],
+		SkillRequirements: toSkills(skill.SkillRequirements),
+		Observations:      skill.Observations,
+		Metadata:          toMetadata(skill.Timestamps, skill.Revision),
+	}
+}
+
+func toSkills(skills []heroes.Skill) []*heroespb.Skill {
+	result := make([]*heroespb.Skill, len(skills))
+	for i, skill := range skills {
+		result[i] = toSkill(skill)
+	}
+
+	return result
+}
+
+func toProficiencies(proficiencies []heroes.Proficiency) []*heroespb.Proficiency {
+	result := make([]*heroespb.Proficiency, len(proficiencies))
+	for i, proficiency := range proficiencies {
+		result[i] = &heroespb.Proficiency{
+			Id:       proficiency.ID,
+			Name:     proficiencyTypes[proficiency.Name],
+			Metadata: toMetadata(proficiency.Timestamps, proficiency.Revision),
+		}
+	}
+
+	return result
+}
+
+func toAttributes(attributes heroes.Attribute) *heroespb.Attributes {
+	return &heroespb.Attributes{
+		Strength:     int32(attributes.Strength),
+		Agility:      int32(attributes.Agility),
+		Intelligence: int32(attributes.Intelligence),
+		Willpower:    int32(attributes.Willpower),
+	}
+}
+
+func toMetadata(timestamps heroes.Timestamps, revision heroes.Revision) *heroespb.Metadata {
+	return &heroespb.Metadata{
+		Version:   revision.Version,
+		CreatedAt: timestamppb.New(timestamps.CreatedAt),
+		UpdatedAt: timestamppb.New(timestamps.UpdatedAt),
+	}
+}
+
+// roleOf finds the heroes.Role mapped to the provided protobuf value.
+func roleOf(value heroespb.Role) (heroes.Role, bool) {
+	for role, mapped := range roles {
+		if mapped == value {
+			return role, true
+		}
+	}
+
+	return "", false
+}
+
+// skillTypeOf finds the heroes.SkillType mapped to the provided protobuf value.
+func skillTypeOf(value heroespb.SkillType) (heroes.SkillType, bool) {
+	for skillType, mapped := range skillTypes {
+		if mapped == value {
+			return skillType, true
+		}
+	}
+
+	return "", false
+}
+
+// sourceOf finds the heroes.Source mapped to the provided protobuf value.
+func sourceOf(value heroespb.Source) (heroes.Source, bool) {
+	for source, mapped := range sources {
+		if mapped == value {
+			return source, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/heroespb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// errUnavailable hides repository failures from clients, as REST routes do.
+var errUnavailable = status.Error(codes.Internal, "Unable to process your request right now. Please check with system administrator.")
+
+// Server implements heroespb.HeroesServiceServer on top of the same Repository used by the REST API.
+type Server struct {
+	heroespb.UnimplementedHeroesServiceServer
+	repository database.Repository
+}
+
+// NewServer constructs a new Server so we don't need to expose its internal fields.
+func NewServer(r database.Repository) *Server {
+	return &Server{repository: r}
+}
+
+// Register builds a grpc.Server serving the heroes service, along with server reflection and the health service.
+func Register(r database.Repository, options ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(options...)
+	heroespb.RegisterHeroesServiceServer(server, NewServer(r))
+	reflection.Register(server)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(heroespb.HeroesService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	return server
+}
+
+// ListRaces returns every race, without associations.
+func (s *Server) ListRaces(ctx context.Context, request *heroespb.ListRacesRequest) (*heroespb.ListRacesResponse, error) {
+	var races []heroes.Race
+	if !s.repository.FindAll(&races) {
+		return nil, errUnavailable
+	}
+
+	return &heroespb.ListRacesResponse{Races: toRaces(races)}, nil
+}
+
+// GetRace returns a race along with its skills and recommended classes.
+func (s *Server) GetRace(ctx context.Context, request *heroespb.GetRaceRequest) (*heroespb.Race, error) {
+	var race heroes.Race
+	if !s.repository.FindByID(&race, request.GetId()) {
+		return nil, notFound(request.GetId())
+	}
+
+	return toRace(race), nil
+}
+
+// ListClasses returns every class, without associations. Can be filtered by role.
+func (s *Server) ListClasses(ctx context.Context, request *heroespb.ListClassesRequest) (*heroespb.ListClassesResponse, error) {
+	filter := heroes.Class{}
+	if request.GetRole() != heroespb.Role_ROLE_UNSPECIFIED {
+		role, ok := roleOf(request.GetRole())
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown role: %s", request.GetRole())
+		}
+		filter.Role = role
+	}
+
+	var classes []heroes.Class
+	if !s.repository.FindByField(&classes, &filter) {
+		return nil, errUnavailable
+	}
+
+	return &heroespb.ListClassesResponse{Classes: toClasses(classes)}, nil
+}
+
+// GetClass returns a class along with its proficiencies and skills.
+func (s *Server) GetClass(ctx context.Context, request *heroespb.GetClassRequest) (*heroespb.Class, error) {
+	var class heroes.Class
+	if !s.repository.FindByID(&class, request.GetId()) {
+		return nil, notFound(request.GetId())
+	}
+
+	return toClass(class), nil
+}
+
+// ListSkills returns every skill, without requirements. Can be filtered by type and source.
+func (s *Server) ListSkills(ctx context.Context, request *heroespb.ListSkillsRequest) (*heroespb.ListSkillsResponse, error) {
+	filter := heroes.Skill{}
+	if request.GetType() != heroespb.SkillType_SKILL_TYPE_UNSPECIFIED {
+		skillType, ok := skillTypeOf(request.GetType())
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown skill type: %s", request.GetType())
+		}
+		filter.Type = skillType
+	}
+
+	if request.GetSource() != heroespb.Source_SOURCE_UNSPECIFIED {
+		source, ok := sourceOf(request.GetSource())
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown source: %s", request.GetSource())
+		}
+		filter.Source = source
+	}
+
+	var skills []heroes.Skill
+	if !s.repository.FindByField(&skills, &filter) {
+		return nil, errUnavailable
+	}
+
+	return &heroespb.ListSkillsResponse{Skills: toSkills(skills)}, nil
+}
+
+// GetSkill returns a skill along with its requirements.
+func (s *Server) GetSkill(ctx context.Context, request *heroespb.GetSkillRequest) (*heroespb.Skill, error) {
+	var skill heroes.Skill
+	if !s.repository.FindByID(&skill, request.GetId()) {
+		return nil, notFound(request.GetId())
+	}
+
+	return toSkill(skill), nil
+}
+
+// ListProficiencies returns every proficiency.
+func (s *Server) ListProficiencies(ctx context.Context, request *heroespb.ListProficienciesRequest) (*heroespb.ListProficienciesResponse, error) {
+	var proficiencies []heroes.Proficiency
+	if !s.repository.FindAll(&proficiencies) {
+		return nil, errUnavailable
+	}
+
+	return &heroespb.ListProficienciesResponse{Proficiencies: toProficiencies(proficiencies)}, nil
+}
+
+func notFound(id uint64) error {
+	return status.Errorf(codes.NotFound, "Resource not found: %d", id)
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+)
+
+type key struct {
+	entityType string
+	id         uint64
+}
+
+// Index is an in-process inverted index, for deployments that would rather not depend on Postgres full text search.
+// It ranks like SQLSearcher: field weight times how rare each matched word is, summed over query words.
+type Index struct {
+	mutex    sync.RWMutex
+	docs     map[key]Result
+	postings map[string]map[key]float64
+	terms    []string // Sorted keys of postings, for prefix lookups. Nil when stale.
+}
+
+// NewIndex constructs an empty index.
+func NewIndex() *Index {
+	return &Index{docs: map[key]Result{}, postings: map[string]map[key]float64{}}
+}
+
+// Load indexes every race, class and skill of the repository.
+func (x *Index) Load(repository database.Repository) error {
+	var races []heroes.Race
+	var classes []heroes.Class
+	var skills []heroes.Skill
+	for _, dest := range []interface{}{&races, &classes, &skills} {
+		if !repository.FindAll(dest) {
+			return database.ErrNotFound
+		}
+	}
+
+	for _, race := range races {
+		x.Add(race)
+	}
+	for _, class := range classes {
+		x.Add(class)
+	}
+	for _, skill := range skills {
+		x.Add(skill)
+	}
+
+	return nil
+}
+
+// Add indexes the race, class or skill, replacing its previous version. Other values are ignored.
+func (x *Index) Add(entity interface{}) {
+	doc, ok := documentOf(entity)
+	if !ok {
+		return
+	}
+
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	k := key{doc.Type, doc.ID}
+	x.remove(k)
+	x.docs[k] = doc.Result
+	for _, f := range doc.fields {
+		for _, term := range Terms(f.text) {
+			if x.postings[term] == nil {
+				x.postings[term] = map[key]float64{}
+				x.terms = nil
+			}
+
+			// A word found in several fields counts with its best weight.
+			x.postings[term][k] = math.Max(x.postings[term][k], f.weight)
+		}
+	}
+}
+
+// Remove drops the entity of the provided type, such as Races, from the index.
+func (x *Index) Remove(entityType string, id uint64) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	x.remove(key{entityType, id})
+}
+
+func (x *Index) remove(k key) {
+	if _, ok := x.docs[k]; !ok {
+		return
+	}
+
+	delete(x.docs, k)
+	for term, docs := range x.postings {
+		if delete(docs, k); len(docs) == 0 {
+			delete(x.postings, term)
+			x.terms = nil
+		}
+	}
+}
+
+// Listener keeps the index up to date with every committed write, reloading changed entities from the repository.
+func (x *Index) Listener(repository database.Repository) database.ChangeListener {
+	models := map[string]func() interface{}{
+		Races:   func() interface{} { return &heroes.Race{} },
+		Classes: func() interface{} { return &heroes.Class{} },
+		Skills:  func() interface{} { return &heroes.Skill{} },
+	}
+
+	return func(change database.Change) {
+		model, ok := models[change.EntityType]
+		if !ok {
+			return
+		}
+
+		if change.Action == database.Deleted {
+			x.Remove(change.EntityType, change.EntityID)
+			return
+		}
+
+		entity := model()
+		if !repository.FindByID(entity, change.EntityID) {
+			log.Printf("Unable to reindex %s %d after %s.", change.EntityType, change.EntityID, change.Action)
+			return
+		}
+		x.Add(entity)
+	}
+}
+
+// Search ranks entities having any of the query words, or words they prefix.
+// Prefix matches count half, so exact words rank first.
+func (x *Index) Search(query string, limit int) ([]Result, error) {
+	x.mutex.RLock()
+	for x.terms == nil {
+		// Writes may make the terms stale again between rebuilding and reading them, so they are checked once more.
+		x.mutex.RUnlock()
+		x.rebuild()
+		x.mutex.RLock()
+	}
+	defer x.mutex.RUnlock()
+
+	scores := map[key]float64{}
+	for _, word := range Terms(query) {
+		for i := sort.SearchStrings(x.terms, word); i < len(x.terms) && strings.HasPrefix(x.terms[i], word); i++ {
+			term := x.terms[i]
+			rarity := math.Log(1 + float64(len(x.docs))/float64(len(x.postings[term])))
+			if term != word {
+				rarity /= 2
+			}
+
+			for k, weight := range x.postings[term] {
+				scores[k] += weight * rarity
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for k, score := range scores {
+		result := x.docs[k]
+		result.Rank = score
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		if results[i].Type != results[j].Type {
+			return results[i].Type < results[j].Type
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// rebuild sorts the terms of the postings, unless another search already did.
+func (x *Index) rebuild() {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if x.terms != nil {
+		return
+	}
+
+	x.terms = make([]string, 0, len(x.postings))
+	for term := range x.postings {
+		x.terms = append(x.terms, term)
+	}
+	sort.Strings(x.terms)
+}
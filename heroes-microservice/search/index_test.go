@@ -0,0 +1,82 @@
+package search
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+func Test_Index_ADD(t *testing.T) {
+	x := NewIndex()
+	x.Add(heroes.Race{ID: 1, Name: "Anão", Description: "Mineradores das montanhas."})
+	x.Add(heroes.Race{ID: 1, Name: "Anão da Colina"})
+
+	if results, _ := x.Search("mineradores", 10); len(results) != 0 {
+		t.Error("Expected the replaced description to be left out, got:", results)
+	}
+
+	results, _ := x.Search("anao colina", 10)
+	if len(results) != 1 || results[0].Name != "Anão da Colina" || results[0].Rank <= 0 {
+		t.Error("Expected the new version to be found once, got:", results)
+	}
+
+	if len(x.docs) != 1 || x.postings["mineradores"] != nil {
+		t.Error("Expected stale postings to be dropped, got:", x.postings)
+	}
+}
+
+func Test_Index_LIMIT(t *testing.T) {
+	x := NewIndex()
+	for id := uint64(1); id <= 5; id++ {
+		x.Add(heroes.Skill{ID: id, Name: "Golpe", Description: fmt.Sprint("Golpe número ", id)})
+	}
+
+	results, _ := x.Search("golpe", 3)
+	if len(results) != 3 || results[0].ID != 1 || results[2].ID != 3 {
+		t.Error("Expected ties ranked by ID up to the limit, got:", results)
+	}
+}
+
+func Test_Index_REMOVE(t *testing.T) {
+	x := NewIndex()
+	x.Add(heroes.Class{ID: 1, Name: "Bardo"})
+	x.Remove(Races, 1)
+
+	if results, _ := x.Search("bardo", 10); len(results) != 1 {
+		t.Error("Expected entities of other types to be kept, got:", results)
+	}
+
+	x.Remove(Classes, 1)
+	if results, _ := x.Search("bardo", 10); len(results) != 0 || len(x.postings) != 0 {
+		t.Error("Expected the class and its postings to be dropped, got:", results)
+	}
+}
+
+func Test_Index_CONCURRENT(t *testing.T) {
+	x := NewIndex()
+	x.Add(heroes.Race{ID: 1, Name: "Humano"})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for id := uint64(2); id < 500; id++ {
+			x.Add(heroes.Race{ID: id, Name: fmt.Sprint("Raça ", id)})
+			x.Remove(Races, id)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			if results, _ := x.Search("humano", 10); len(results) != 1 {
+				t.Error("Expected searches to find entities while others are written, got:", results)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+// Package search finds races, classes and skills by the words in their names, descriptions, bonuses and observations,
+// ranking names above descriptions, and descriptions above the rest. Matching ignores case and accents, so "magia"
+// finds "Mágia", and query words also match longer words they prefix.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// Entity types found by Search, matching their table names.
+const (
+	Races   = "races"
+	Classes = "classes"
+	Skills  = "skills"
+)
+
+// Result is an entity matching the query. Higher ranks are better matches.
+type Result struct {
+	Type        string  `json:"type"`
+	ID          uint64  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Rank        float64 `json:"rank"`
+}
+
+// Searcher finds the entities best matching a free text query, best first.
+type Searcher interface {
+	Search(query string, limit int) ([]Result, error)
+}
+
+// Field weights, following Postgres' setweight labels.
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightC = 0.2
+)
+
+type field struct {
+	text   string
+	weight float64
+}
+
+// document is the searchable text of an entity.
+type document struct {
+	Result
+	fields []field
+}
+
+// documentOf describes a race, class or skill, either as value or pointer. Other values are not searchable.
+func documentOf(entity interface{}) (document, bool) {
+	switch e := entity.(type) {
+	case *heroes.Race:
+		return documentOf(*e)
+	case *heroes.Class:
+		return documentOf(*e)
+	case *heroes.Skill:
+		return documentOf(*e)
+	case heroes.Race:
+		return document{Result{Races, e.ID, e.Name, e.Description, 0}, []field{{e.Name, weightA}, {e.Description, weightB}}}, true
+	case heroes.Class:
+		return document{Result{Classes, e.ID, e.Name, e.Description, 0}, []field{{e.Name, weightA}, {e.Description, weightB}}}, true
+	case heroes.Skill:
+		return document{Result{Skills, e.ID, e.Name, e.Description, 0}, []field{{e.Name, weightA}, {e.Description, weightB}, {e.Bonus, weightC}, {e.Observations, weightC}}}, true
+	default:
+		return document{}, false
+	}
+}
+
+// Terms splits the text into folded words, dropping single letters.
+func Terms(text string) []string {
+	words := strings.FieldsFunc(heroes.Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) > 1 {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
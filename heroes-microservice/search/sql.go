@@ -0,0 +1,70 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SQLSearcher searches with Postgres full text search. It needs the unaccent extension.
+type SQLSearcher struct {
+	db *gorm.DB
+}
+
+// NewSQLSearcher constructs a searcher over the tables of db.
+func NewSQLSearcher(db *gorm.DB) *SQLSearcher {
+	return &SQLSearcher{db}
+}
+
+// vector weights the searchable columns of a table. The simple configuration doesn't stem words, since texts mix
+// Portuguese and English, while unaccent makes matching accent insensitive.
+func vector(columns map[string]string) string {
+	var parts []string
+	for _, label := range []string{"A", "B", "C"} {
+		if column, ok := columns[label]; ok {
+			parts = append(parts, fmt.Sprintf("setweight(to_tsvector('simple', unaccent(%s)), '%s')", column, label))
+		}
+	}
+
+	return strings.Join(parts, " || ")
+}
+
+var tables = []struct {
+	name    string
+	columns map[string]string
+}{
+	{Races, map[string]string{"A": "coalesce(name, '')", "B": "coalesce(description, '')"}},
+	{Classes, map[string]string{"A": "coalesce(name, '')", "B": "coalesce(description, '')"}},
+	{Skills, map[string]string{"A": "coalesce(name, '')", "B": "coalesce(description, '')", "C": "coalesce(bonus, '') || ' ' || coalesce(observations, '')"}},
+}
+
+// Search ranks entities having any of the query words, or words they prefix, with ts_rank.
+func (s *SQLSearcher) Search(query string, limit int) ([]Result, error) {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return []Result{}, nil
+	}
+
+	// Terms only hold letters and digits, so they can't break out of the tsquery syntax.
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	tsquery := strings.Join(terms, " | ")
+
+	var selects []string
+	var args []interface{}
+	for _, table := range tables {
+		v := vector(table.columns)
+		selects = append(selects, fmt.Sprintf("SELECT '%s' AS type, id, name, description, ts_rank(%s, to_tsquery('simple', ?)) AS rank FROM %s WHERE deleted_at IS NULL AND %s @@ to_tsquery('simple', ?)", table.name, v, table.name, v))
+		args = append(args, tsquery, tsquery)
+	}
+
+	results := []Result{}
+	sql := strings.Join(selects, " UNION ALL ") + " ORDER BY rank DESC, type, id LIMIT ?"
+	if err := s.db.Raw(sql, append(args, limit)...).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-data"
+)
+
+// maxSuggestions caps how many names Suggest returns.
+const maxSuggestions = 3
+
+// Distance counts the single letter insertions, deletions, substitutions and swaps of adjacent letters turning a into
+// b, ignoring case and accents. "warior" and "figther" are both one edit away from "Warrior" and "Fighter".
+func Distance(a, b string) int {
+	s, t := []rune(heroes.Fold(a)), []rune(heroes.Fold(b))
+
+	// Only the last three rows are needed to account for swaps.
+	previous, last, current := make([]int, len(t)+1), make([]int, len(t)+1), make([]int, len(t)+1)
+	for j := range last {
+		last[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(last[j]+1, current[j-1]+1, last[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				current[j] = min(current[j], previous[j-2]+1)
+			}
+		}
+
+		previous, last, current = last, current, previous
+	}
+
+	return last[len(t)]
+}
+
+// Suggest picks the names closest to the one given, closest first, as long as they are a few edits away.
+// Longer names tolerate more typos: one edit every three letters.
+func Suggest(name string, names []string) []string {
+	tolerance := len([]rune(name)) / 3
+	if tolerance < 1 {
+		tolerance = 1
+	}
+
+	distances := map[string]int{}
+	var suggestions []string
+	for _, candidate := range names {
+		if _, seen := distances[candidate]; seen {
+			continue
+		}
+
+		if distance := Distance(name, candidate); distance <= tolerance {
+			distances[candidate] = distance
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if distances[suggestions[i]] != distances[suggestions[j]] {
+			return distances[suggestions[i]] < distances[suggestions[j]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	return suggestions
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Distance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"Warrior", "Warrior", 0},
+		{"warrior", "WARRIOR", 0},
+		{"mágia", "Magia", 0},
+		{"warior", "Warrior", 1},
+		{"figther", "Fighter", 1},
+		{"mage", "maze", 1},
+		{"", "elf", 3},
+		{"elf", "", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		if distance := Distance(test.a, test.b); distance != test.expected {
+			t.Errorf("Expected distance from %q to %q to be %d, got: %d", test.a, test.b, test.expected, distance)
+		}
+	}
+}
+
+func Test_Suggest(t *testing.T) {
+	names := []string{"Warrior", "Wizard", "Warlock", "Ranger", "Rogue", "Warrior"}
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"warior", []string{"Warrior"}},
+		{"rogeu", []string{"Rogue"}},
+		{"wizzard", []string{"Wizard"}},
+		{"war", nil},
+		{"paladin", nil},
+	}
+
+	for _, test := range tests {
+		if suggestions := Suggest(test.name, names); !reflect.DeepEqual(suggestions, test.expected) {
+			t.Errorf("Expected %q to suggest %v, got: %v", test.name, test.expected, suggestions)
+		}
+	}
+}
+
+func Test_Suggest_LIMIT(t *testing.T) {
+	suggestions := Suggest("cat", []string{"hat", "bat", "cut", "cap", "cat"})
+	if expected := []string{"cat", "bat", "cap"}; !reflect.DeepEqual(suggestions, expected) {
+		t.Errorf("Expected the %d closest names, closest first, got: %v", maxSuggestions, suggestions)
+	}
+}
@@ -0,0 +1,347 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// batchSize caps how many deliveries are attempted at once.
+const batchSize = 10
+
+// Dispatcher turns change events into deliveries and sends them, retrying failures with exponential backoff.
+// Deliveries are persisted, so pending retries survive restarts.
+type Dispatcher struct {
+	db     *gorm.DB
+	client *http.Client
+	nudge  chan struct{}
+
+	// MaxAttempts before a delivery is moved to the dead-letter list.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry. It doubles on every subsequent one, up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between retries.
+	MaxDelay time.Duration
+	// Lease is how long claimed deliveries are kept from other dispatchers while being sent. Deliveries left behind by
+	// a dispatcher stopping midway are attempted again once it expires.
+	Lease time.Duration
+}
+
+// NewDispatcher constructs a Dispatcher with sensible retry defaults: 8 attempts, starting at 2s up to 1h apart, and
+// 5m to send each batch.
+func NewDispatcher(db *gorm.DB, client *http.Client) *Dispatcher {
+	return &Dispatcher{
+		db:          db,
+		client:      client,
+		nudge:       make(chan struct{}, 1),
+		MaxAttempts: 8,
+		BaseDelay:   2 * time.Second,
+		MaxDelay:    time.Hour,
+		Lease:       5 * time.Minute,
+	}
+}
+
+// Run consumes events from the feed and sends due deliveries until stop is closed.
+// Retries are checked every interval.
+func (d *Dispatcher) Run(feed *events.Feed, interval time.Duration, stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		d.consume(feed, interval, stop)
+	}()
+
+	go func() {
+		defer wg.Done()
+		d.deliver(interval, stop)
+	}()
+
+	wg.Wait()
+}
+
+// consume enqueues deliveries for every event, resuming after the last enqueued one on startup, so events written
+// while no dispatcher was running are not missed, and from the last consumed event whenever the feed drops us.
+func (d *Dispatcher) consume(feed *events.Feed, interval time.Duration, stop <-chan struct{}) {
+	last, err := d.LastEnqueued()
+	for err != nil {
+		log.Println("Error while finding the last enqueued event: ", err)
+		select {
+		case <-stop:
+			return
+		case <-time.After(interval):
+		}
+
+		last, err = d.LastEnqueued()
+	}
+
+	for {
+		replay, live, err := feed.Subscribe(last, last > 0)
+		if errors.Is(err, events.ErrTooFarBehind) {
+			// Catching up page by page gets close enough to resume.
+			var page []events.Event
+			if page, err = feed.Since(last); err == nil {
+				for _, event := range page {
+					d.enqueueLogged(event)
+					last = event.ID
+				}
+				continue
+			}
+		}
+
+		if err != nil {
+			log.Println("Error while subscribing to events: ", err)
+			select {
+			case <-stop:
+				return
+			case <-time.After(interval):
+				continue
+			}
+		}
+
+		for _, event := range replay {
+			d.enqueueLogged(event)
+			last = event.ID
+		}
+
+		for open := true; open; {
+			var event events.Event
+			select {
+			case <-stop:
+				feed.Unsubscribe(live)
+				return
+			case event, open = <-live:
+				if open {
+					d.enqueueLogged(event)
+					last = event.ID
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-d.nudge:
+		}
+
+		// Keep going while there are full batches, so a burst of events is not throttled by the ticker.
+		for {
+			if attempted, err := d.DeliverDue(); err != nil || attempted < batchSize {
+				break
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) enqueueLogged(event events.Event) {
+	if err := d.Enqueue(event); err != nil {
+		log.Println("Error while enqueueing webhook deliveries: ", err)
+	}
+}
+
+// LastEnqueued is the ID of the latest event deliveries were enqueued for, zero when none was. Events matching no
+// subscription are not accounted for, so they may be consumed again, which enqueues nothing.
+func (d *Dispatcher) LastEnqueued() (uint64, error) {
+	var last uint64
+	err := d.db.Model(&Delivery{}).Select("COALESCE(MAX(event_id), 0)").Scan(&last).Error
+	return last, err
+}
+
+// Enqueue creates a pending delivery of the event for every matching subscription.
+// Enqueueing the same event twice is a no-op, so several instances can consume the same feed.
+func (d *Dispatcher) Enqueue(event events.Event) error {
+	var subscriptions []Subscription
+	if err := d.db.Find(&subscriptions).Error; err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []Delivery
+	for _, subscription := range subscriptions {
+		if subscription.Matches(event) {
+			deliveries = append(deliveries, Delivery{
+				SubscriptionID: subscription.ID,
+				EventID:        event.ID,
+				EventName:      fmt.Sprintf("%s.%s", event.EntityType, event.Action),
+				Payload:        database.JSON(payload),
+				Status:         Pending,
+				NextAttemptAt:  time.Now(),
+			})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return err
+	}
+
+	d.wake()
+	return nil
+}
+
+// DeliverDue attempts the pending deliveries whose next attempt is due, returning how many were attempted.
+// Deliveries are claimed for the Lease before being sent, so concurrent dispatchers never send the same one twice,
+// while no transaction is held open during requests.
+func (d *Dispatcher) DeliverDue() (attempted int, err error) {
+	due, err := d.claim()
+	if err == nil {
+		for i := range due {
+			d.attempt(&due[i])
+		}
+
+		err = d.db.Transaction(func(tx *gorm.DB) error {
+			for i := range due {
+				if err := tx.Omit(clause.Associations).Save(&due[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	if err != nil {
+		log.Println("Error while delivering webhooks: ", err)
+	}
+
+	return len(due), err
+}
+
+// claim locks a batch of due deliveries, skipping the ones locked by others, and pushes their next attempt past the
+// Lease before committing, so they are no longer due for anyone else.
+func (d *Dispatcher) claim() (due []Delivery, err error) {
+	err = d.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).Preload("Subscription").
+			Where("status = ? AND next_attempt_at <= ?", Pending, now).Order("id ASC").Limit(batchSize).Find(&due).Error; err != nil {
+			return err
+		}
+
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(due))
+		for i, delivery := range due {
+			ids[i] = delivery.ID
+		}
+
+		return tx.Model(&Delivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(d.Lease)).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
+
+// Retry moves a dead delivery back to pending, with a fresh set of attempts.
+func (d *Dispatcher) Retry(id uint64) error {
+	result := d.db.Model(&Delivery{}).Where("id = ? AND status = ?", id, Dead).
+		Updates(map[string]interface{}{"status": Pending, "attempts": 0, "next_attempt_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return database.ErrNotFound
+	}
+
+	d.wake()
+	return nil
+}
+
+func (d *Dispatcher) wake() {
+	select {
+	case d.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// attempt sends the delivery once and schedules the next attempt if it failed.
+func (d *Dispatcher) attempt(delivery *Delivery) {
+	delivery.Attempts++
+
+	var err error
+	if delivery.ResponseStatus, err = d.send(delivery); err == nil {
+		delivery.Status = Delivered
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.MaxAttempts {
+		delivery.Status = Dead
+		return
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(d.Backoff(delivery.Attempts))
+}
+
+// Backoff is the wait after the provided number of failed attempts.
+func (d *Dispatcher) Backoff(attempts int) time.Duration {
+	delay := d.BaseDelay
+	for i := 1; i < attempts && delay < d.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > d.MaxDelay {
+		delay = d.MaxDelay
+	}
+
+	return delay
+}
+
+func (d *Dispatcher) send(delivery *Delivery) (int, error) {
+	if delivery.Subscription == nil {
+		return 0, fmt.Errorf("subscription %d no longer exists", delivery.SubscriptionID)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, delivery.Subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Heroes-Event", delivery.EventName)
+	request.Header.Set("X-Heroes-Delivery", strconv.FormatUint(delivery.ID, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Subscription.Secret, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
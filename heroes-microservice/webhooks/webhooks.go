@@ -0,0 +1,117 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/database"
+	"github.com/tgl-dogg/golang-microservice-play/heroes-microservice/events"
+)
+
+// Status is where a Delivery stands in its lifecycle.
+type Status string
+
+const (
+	// Pending deliveries are waiting for their next attempt.
+	Pending Status = "pending"
+	// Delivered deliveries got a successful (2xx) response.
+	Delivered Status = "delivered"
+	// Dead deliveries ran out of attempts. They form the dead-letter list and can be retried manually.
+	Dead Status = "dead"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body, keyed with the subscription secret.
+const SignatureHeader = "X-Heroes-Signature"
+
+// Subscription registers a URL to be called whenever a matching change happens.
+// Empty EntityTypes or Actions match every entity type or action.
+type Subscription struct {
+	ID          uint64     `json:"id" gorm:"primary_key"`
+	URL         string     `json:"url" binding:"required,url"`
+	Secret      string     `json:"secret,omitempty"`
+	EntityTypes StringList `json:"entity_types"`
+	Actions     StringList `json:"actions"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Matches tells whether the event passes the subscription filters.
+func (s *Subscription) Matches(event events.Event) bool {
+	return s.EntityTypes.matches(event.EntityType) && s.Actions.matches(string(event.Action))
+}
+
+// Delivery is a single event to be sent to a single subscription, along with the outcome of its latest attempt.
+type Delivery struct {
+	ID             uint64        `json:"id" gorm:"primary_key"`
+	SubscriptionID uint64        `json:"subscription_id" gorm:"uniqueIndex:idx_delivery_event"`
+	Subscription   *Subscription `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	EventID        uint64        `json:"event_id" gorm:"uniqueIndex:idx_delivery_event"`
+	EventName      string        `json:"event_name"`
+	Payload        database.JSON `json:"payload"`
+	Status         Status        `json:"status" gorm:"index"`
+	Attempts       int           `json:"attempts"`
+	ResponseStatus int           `json:"response_status"`
+	LastError      string        `json:"last_error"`
+	NextAttemptAt  time.Time     `json:"next_attempt_at" gorm:"index"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// Sign computes the value of SignatureHeader for the provided body, so receivers can check it came from us.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// StringList is a list of values stored as comma separated text.
+type StringList []string
+
+func (l StringList) matches(value string) bool {
+	if len(l) == 0 {
+		return true
+	}
+
+	for _, item := range l {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value stores the list as comma separated text.
+func (l StringList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+// Scan reads a list stored as comma separated text.
+func (l *StringList) Scan(src interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+	case []byte:
+		text = string(value)
+	case string:
+		text = value
+	default:
+		return fmt.Errorf("unsupported StringList column type %T", src)
+	}
+
+	*l = nil
+	if text != "" {
+		*l = strings.Split(text, ",")
+	}
+
+	return nil
+}
+
+// GormDataType maps StringList to a text column.
+func (StringList) GormDataType() string {
+	return "text"
+}